@@ -24,7 +24,8 @@ type AuthHandler struct{
 }
 
 type sendOtpJSON struct {
-	Email string `json:"email" binding:"required"`
+	Email  string `json:"email" binding:"required"`
+	Locale string `json:"locale"`
 }
 
 type registerRequestJSON struct {
@@ -40,6 +41,16 @@ type registerRequestJSON struct {
 	Enable2FA             bool `json:"enable_2fa"`
 	OtpCode               string `json:"otp_code"`
 	TurnstileToken	   string `json:"turnstile_token"`
+	Locale                string `json:"locale"`
+}
+
+// requestLocale is the language a signup's emails go out in: the one the
+// client asked for, or else the browser's Accept-Language.
+func requestLocale(c *gin.Context, locale string) string {
+	if locale != "" {
+		return locale
+	}
+	return c.GetHeader("Accept-Language")
 }
 
 type googleLoginJson struct {
@@ -172,7 +183,8 @@ func (h *AuthHandler) SendOtp(c *gin.Context){
 	}
 
 	res, err := h.UserClient.SendOtp(context.Background(), &pb.SendOtpRequest{
-		Email: jsonReq.Email,
+		Email:  jsonReq.Email,
+		Locale: requestLocale(c, jsonReq.Locale),
 	})
  
 	if err != nil {
@@ -224,6 +236,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Enable_2Fa:            jsonReq.Enable2FA,
 		OtpCode:               jsonReq.OtpCode,
 		TurnstileToken: jsonReq.TurnstileToken,
+		Locale:         requestLocale(c, jsonReq.Locale),
 	}
 
 	res, err := h.UserClient.RegisterUser(context.Background(), grpcReq)
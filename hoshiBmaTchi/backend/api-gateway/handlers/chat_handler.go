@@ -3,8 +3,10 @@ package handlers
 import (
 	"net/http"
 
-	"github.com/gin-gonic/gin"
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/chat"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ChatHandler struct {
@@ -15,18 +17,43 @@ func NewChatHandler(client pb.ChatServiceClient) *ChatHandler {
 	return &ChatHandler{client: client}
 }
 
+// GetCallToken issues call credentials to the signed-in user. The token is
+// always for the caller: a user_id in the body may only repeat it.
 func (h *ChatHandler) GetCallToken(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	var req pb.GetCallTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 		return
 	}
+	if req.UserId != "" && req.UserId != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot request a call token for another user"})
+		return
+	}
+	req.UserId = userID
 
 	resp, err := h.client.GetCallToken(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		s, _ := status.FromError(err)
+		switch s.Code() {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": s.Message()})
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": s.Message()})
+		case codes.NotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": s.Message()})
+		case codes.Unavailable:
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": s.Message()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": s.Message()})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
-}
\ No newline at end of file
+}
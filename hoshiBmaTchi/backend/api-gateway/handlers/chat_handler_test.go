@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/api-gateway/handlers"
+	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/chat"
+)
+
+// fakeChat issues tokens only to members of its one conversation, like the
+// chat service does.
+type fakeChat struct {
+	pb.ChatServiceClient
+	members map[string]bool
+	asked   []string
+}
+
+func (f *fakeChat) GetCallToken(ctx context.Context, in *pb.GetCallTokenRequest, opts ...grpc.CallOption) (*pb.GetCallTokenResponse, error) {
+	f.asked = append(f.asked, in.UserId)
+	if !f.members[in.UserId] {
+		return nil, status.Error(codes.PermissionDenied, "not a participant of this conversation")
+	}
+	return &pb.GetCallTokenResponse{Token: "token-for-" + in.UserId}, nil
+}
+
+func TestGetCallToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const member, outsider = "member-id", "outsider-id"
+
+	tests := []struct {
+		name      string
+		caller    string
+		body      string
+		wantCode  int
+		wantAsked []string
+	}{
+		{"member", member, `{"conversation_id":"c"}`, http.StatusOK, []string{member}},
+		{"member naming themselves", member, `{"conversation_id":"c","user_id":"member-id"}`, http.StatusOK, []string{member}},
+		{"non-member", outsider, `{"conversation_id":"c"}`, http.StatusForbidden, []string{outsider}},
+		{"non-member naming a member", outsider, `{"conversation_id":"c","user_id":"member-id"}`, http.StatusForbidden, nil},
+		{"not signed in", "", `{"conversation_id":"c","user_id":"member-id"}`, http.StatusUnauthorized, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat := &fakeChat{members: map[string]bool{member: true}}
+			h := handlers.NewChatHandler(chat)
+
+			r := gin.New()
+			r.POST("/token", func(c *gin.Context) {
+				if tt.caller != "" {
+					c.Set("userID", tt.caller)
+				}
+				h.GetCallToken(c)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantCode, w.Body.String())
+			}
+			if strings.Join(chat.asked, ",") != strings.Join(tt.wantAsked, ",") {
+				t.Errorf("tokens asked for %v, want %v", chat.asked, tt.wantAsked)
+			}
+			if w.Code == http.StatusOK && !strings.Contains(w.Body.String(), "token-for-"+tt.caller) {
+				t.Errorf("token was not issued to the caller: %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package events is the contract for the events services exchange over
+// RabbitMQ, and a publisher that speaks it. Events travel as JSON.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// SchemaVersion is the version of NotificationEvent this package writes.
+// Consumers accept every version up to it and reject newer ones, so bump it
+// only for changes old consumers cannot read; adding an optional field is not
+// one.
+const SchemaVersion = 1
+
+const (
+	// NotificationExchange is a topic exchange. Events are routed with
+	// "notification.<type>".
+	NotificationExchange = "notification_exchange"
+
+	// EmailExchange is a direct exchange for EmailTask, routed with
+	// EmailRoutingKey.
+	EmailExchange   = "email_exchange"
+	EmailRoutingKey = "send_email"
+)
+
+// Notification types. The notifications service routes on these, so a new
+// producer must add its type here first.
+const (
+	TypeLike       = "like"
+	TypeComment    = "comment"
+	TypeMention    = "mention"
+	TypeFollow     = "follow"
+	TypeStoryLike  = "story_like"
+	TypeStoryReply = "story_reply"
+	TypeMessage    = "message"
+)
+
+// Types lists every notification type consumers must handle.
+var Types = []string{
+	TypeLike,
+	TypeComment,
+	TypeMention,
+	TypeFollow,
+	TypeStoryLike,
+	TypeStoryReply,
+	TypeMessage,
+}
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported notification event version")
+	ErrUnknownType        = errors.New("unknown notification type")
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// NotificationEvent tells the notifications service that something happened
+// to RecipientID. All IDs are UUID strings.
+type NotificationEvent struct {
+	Version int `json:"version"`
+
+	// ID identifies the event, so a consumer can tell a redelivery from a
+	// new event.
+	ID string `json:"id"`
+
+	Type        string `json:"type"`
+	RecipientID string `json:"recipient_id"`
+	SenderID    string `json:"sender_id"`
+	SenderName  string `json:"sender_name"`
+	SenderImage string `json:"sender_image"`
+
+	// EntityID is what the notification links to: a post, a story, a
+	// conversation or, for follows, the follower.
+	EntityID   string    `json:"entity_id"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// RoutingKey is the key the event is published with.
+func (e NotificationEvent) RoutingKey() string {
+	return "notification." + e.Type
+}
+
+// Validate checks that the event can be stored and delivered.
+func (e NotificationEvent) Validate() error {
+	if e.Version < 1 || e.Version > SchemaVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, e.Version)
+	}
+	if !isType(e.Type) {
+		return fmt.Errorf("%w: %q", ErrUnknownType, e.Type)
+	}
+	if !uuidPattern.MatchString(e.RecipientID) {
+		return errors.New("recipient_id must be a UUID")
+	}
+	if !uuidPattern.MatchString(e.SenderID) {
+		return errors.New("sender_id must be a UUID")
+	}
+	if e.EntityID == "" {
+		return errors.New("entity_id is required")
+	}
+	return nil
+}
+
+// DecodeNotification parses and validates an event off the wire. Events
+// published before the schema was versioned carry no version; they had the
+// same fields, so they are read as version 1.
+func DecodeNotification(body []byte) (NotificationEvent, error) {
+	var event NotificationEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return event, err
+	}
+	if event.Version == 0 {
+		event.Version = 1
+	}
+	return event, event.Validate()
+}
+
+func isType(t string) bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Email templates the notifications service renders, with the Data keys
+// each one reads.
+const (
+	TemplateOTP                  = "otp"                   // code
+	TemplateLoginCode            = "login_code"            // code
+	TemplatePasswordReset        = "password_reset"        // link
+	TemplateWelcome              = "welcome"               // name
+	TemplateVerificationApproved = "verification_approved" // name
+	TemplateReportUpdate         = "report_update"         // name
+	TemplatePostRemoved          = "post_removed"          // no data
+	TemplateMessageExport        = "message_export"        // link, expires
+)
+
+// EmailTask asks the notifications service to send one email. A task naming
+// a Template is rendered from it in Locale, English by default, and gets
+// both an HTML and a plain-text part; otherwise Body is sent as HTML under
+// Subject.
+type EmailTask struct {
+	Email   string `json:"email"`
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+
+	Template string                 `json:"template,omitempty"`
+	Locale   string                 `json:"locale,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,256 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// publishTimeout bounds how long a publish waits for the broker to confirm.
+	publishTimeout = 5 * time.Second
+
+	// Reconnect attempts back off from minRedialDelay up to maxRedialDelay.
+	minRedialDelay = time.Second
+	maxRedialDelay = 30 * time.Second
+)
+
+var (
+	// ErrNotConfirmed means the broker refused an event, so it was not stored.
+	ErrNotConfirmed = errors.New("broker did not confirm the event")
+
+	// ErrUnroutable means no queue was bound for the event, so the broker
+	// returned it instead of storing it.
+	ErrUnroutable = errors.New("no queue is bound for the event")
+
+	// ErrNotConnected means the connection to the broker was lost and is
+	// being re-established.
+	ErrNotConnected = errors.New("not connected to the broker")
+)
+
+// Publisher publishes events with publisher confirms: a publish returns only
+// once RabbitMQ has queued the event somewhere. If the connection drops, the
+// Publisher dials again in the background and publishes fail with
+// ErrNotConnected until it is back. A nil Publisher drops everything, which
+// lets services run without a broker in tests.
+type Publisher struct {
+	url string
+
+	// mu guards the fields below. An AMQP channel must not be used from
+	// several goroutines at once, and a publish holds mu until its confirm
+	// arrives, so a returned message is always matched to its publish.
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	ch      *amqp.Channel
+	returns chan amqp.Return
+	closed  bool
+}
+
+// NewPublisher connects to RabbitMQ and declares the exchanges events go to.
+func NewPublisher(url string) (*Publisher, error) {
+	conn, ch, err := dial(url)
+	if err != nil {
+		return nil, err
+	}
+	p := &Publisher{url: url}
+	p.mu.Lock()
+	p.attach(conn, ch)
+	p.mu.Unlock()
+	return p, nil
+}
+
+// dial opens a connection and a channel in confirm mode, and declares the
+// exchanges.
+func dial(url string) (*amqp.Connection, *amqp.Channel, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to rabbitmq: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	if err := ch.ExchangeDeclare(NotificationExchange, "topic", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to declare exchange: %w", err)
+	}
+	if err := ch.ExchangeDeclare(EmailExchange, "direct", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to declare exchange: %w", err)
+	}
+	return conn, ch, nil
+}
+
+// attach starts publishing on a fresh connection and watches it for closing.
+// The caller holds p.mu.
+func (p *Publisher) attach(conn *amqp.Connection, ch *amqp.Channel) {
+	p.conn, p.ch = conn, ch
+	// The broker sends a return before the confirm of the same message, so
+	// the buffer only has to hold returns nobody has read yet.
+	p.returns = ch.NotifyReturn(make(chan amqp.Return, 16))
+	go p.redialOnClose(
+		conn.NotifyClose(make(chan *amqp.Error, 1)),
+		ch.NotifyClose(make(chan *amqp.Error, 1)),
+	)
+}
+
+// redialOnClose waits for the connection or its channel to close and then
+// dials until it gets a new one, unless the Publisher was closed.
+func (p *Publisher) redialOnClose(connClosed, chClosed chan *amqp.Error) {
+	var reason *amqp.Error
+	select {
+	case reason = <-connClosed:
+	case reason = <-chClosed:
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	// A channel error leaves the connection open; drop it as well and
+	// start over.
+	p.conn.Close()
+	p.conn, p.ch, p.returns = nil, nil, nil
+	p.mu.Unlock()
+	log.Printf("Lost connection to rabbitmq (%v), reconnecting", reason)
+
+	for delay := minRedialDelay; ; delay = min(2*delay, maxRedialDelay) {
+		time.Sleep(delay)
+
+		conn, ch, err := dial(p.url)
+		if err != nil {
+			log.Printf("Failed to reconnect to rabbitmq: %v", err)
+			continue
+		}
+
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			conn.Close()
+			return
+		}
+		p.attach(conn, ch)
+		p.mu.Unlock()
+		log.Printf("Reconnected to rabbitmq")
+		return
+	}
+}
+
+// Publish sends a notification event. Version, ID and OccurredAt are filled
+// in when left empty, and the event is validated before it goes out.
+func (p *Publisher) Publish(ctx context.Context, event NotificationEvent) error {
+	if p == nil {
+		return nil
+	}
+	if event.Version == 0 {
+		event.Version = SchemaVersion
+	}
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	return p.publish(ctx, NotificationExchange, event.RoutingKey(), event.ID, event)
+}
+
+// SendEmail queues an email for the notifications service to deliver.
+func (p *Publisher) SendEmail(ctx context.Context, task EmailTask) error {
+	if p == nil {
+		return nil
+	}
+	return p.publish(ctx, EmailExchange, EmailRoutingKey, uuid.NewString(), task)
+}
+
+func (p *Publisher) publish(ctx context.Context, exchange, routingKey, messageID string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ch == nil {
+		return fmt.Errorf("failed to publish to %s: %w", exchange, ErrNotConnected)
+	}
+	// Returns left over from publishes that timed out belong to nobody.
+	p.returned(messageID)
+
+	// mandatory makes the broker return the event when no queue is bound
+	// for it, rather than confirming it and dropping it.
+	confirm, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, true, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    messageID,
+		Timestamp:    time.Now(),
+		Body:         body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", exchange, err)
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", exchange, err)
+	}
+	if !acked {
+		return fmt.Errorf("failed to publish to %s: %w", exchange, ErrNotConfirmed)
+	}
+	if p.returned(messageID) {
+		return fmt.Errorf("failed to publish %s to %s: %w", routingKey, exchange, ErrUnroutable)
+	}
+	return nil
+}
+
+// returned drains the returns received so far and reports whether the
+// message with the given ID was among them. The caller holds p.mu.
+func (p *Publisher) returned(messageID string) bool {
+	found := false
+	for {
+		select {
+		case r, ok := <-p.returns:
+			// The channel is closed once the connection drops.
+			if !ok {
+				return found
+			}
+			if r.MessageId == messageID {
+				found = true
+			}
+		default:
+			return found
+		}
+	}
+}
+
+func (p *Publisher) Close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	if p.conn != nil {
+		p.ch.Close()
+		p.conn.Close()
+	}
+}
@@ -3,6 +3,8 @@ module github.com/Hinsane5/hoshiBmaTchi/backend/proto
 go 1.25.3
 
 require (
+	github.com/google/uuid v1.6.0
+	github.com/rabbitmq/amqp091-go v1.10.0
 	google.golang.org/grpc v1.76.0
 	google.golang.org/protobuf v1.36.10
 )
@@ -22,6 +22,107 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// NotificationChannel is where notifications of one kind are delivered.
+type NotificationChannel int32
+
+const (
+	NotificationChannel_CHANNEL_IN_APP NotificationChannel = 0
+	NotificationChannel_CHANNEL_EMAIL  NotificationChannel = 1
+	NotificationChannel_CHANNEL_OFF    NotificationChannel = 2
+)
+
+// Enum value maps for NotificationChannel.
+var (
+	NotificationChannel_name = map[int32]string{
+		0: "CHANNEL_IN_APP",
+		1: "CHANNEL_EMAIL",
+		2: "CHANNEL_OFF",
+	}
+	NotificationChannel_value = map[string]int32{
+		"CHANNEL_IN_APP": 0,
+		"CHANNEL_EMAIL":  1,
+		"CHANNEL_OFF":    2,
+	}
+)
+
+func (x NotificationChannel) Enum() *NotificationChannel {
+	p := new(NotificationChannel)
+	*p = x
+	return p
+}
+
+func (x NotificationChannel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationChannel) Descriptor() protoreflect.EnumDescriptor {
+	return file_users_users_proto_enumTypes[0].Descriptor()
+}
+
+func (NotificationChannel) Type() protoreflect.EnumType {
+	return &file_users_users_proto_enumTypes[0]
+}
+
+func (x NotificationChannel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationChannel.Descriptor instead.
+func (NotificationChannel) EnumDescriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{0}
+}
+
+// DigestFrequency is how often a user is emailed a digest of their unread
+// notifications.
+type DigestFrequency int32
+
+const (
+	DigestFrequency_DIGEST_OFF    DigestFrequency = 0
+	DigestFrequency_DIGEST_DAILY  DigestFrequency = 1
+	DigestFrequency_DIGEST_WEEKLY DigestFrequency = 2
+)
+
+// Enum value maps for DigestFrequency.
+var (
+	DigestFrequency_name = map[int32]string{
+		0: "DIGEST_OFF",
+		1: "DIGEST_DAILY",
+		2: "DIGEST_WEEKLY",
+	}
+	DigestFrequency_value = map[string]int32{
+		"DIGEST_OFF":    0,
+		"DIGEST_DAILY":  1,
+		"DIGEST_WEEKLY": 2,
+	}
+)
+
+func (x DigestFrequency) Enum() *DigestFrequency {
+	p := new(DigestFrequency)
+	*p = x
+	return p
+}
+
+func (x DigestFrequency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DigestFrequency) Descriptor() protoreflect.EnumDescriptor {
+	return file_users_users_proto_enumTypes[1].Descriptor()
+}
+
+func (DigestFrequency) Type() protoreflect.EnumType {
+	return &file_users_users_proto_enumTypes[1]
+}
+
+func (x DigestFrequency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DigestFrequency.Descriptor instead.
+func (DigestFrequency) EnumDescriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{1}
+}
+
 type LoginWithGoogleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	IdToken       string                 `protobuf:"bytes,1,opt,name=id_token,proto3" json:"id_token,omitempty"`
@@ -277,6 +378,7 @@ func (x *VerifyLogin2FARequest) GetOtpCode() string {
 type SendOtpRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"` // Language of the email, e.g. "id"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -318,6 +420,13 @@ func (x *SendOtpRequest) GetEmail() string {
 	return ""
 }
 
+func (x *SendOtpRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type SendOtpResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -480,6 +589,7 @@ type RegisterUserRequest struct {
 	Enable_2Fa            bool                   `protobuf:"varint,10,opt,name=enable_2fa,proto3" json:"enable_2fa,omitempty"`
 	OtpCode               string                 `protobuf:"bytes,11,opt,name=otp_code,proto3" json:"otp_code,omitempty"`
 	TurnstileToken        string                 `protobuf:"bytes,12,opt,name=turnstile_token,proto3" json:"turnstile_token,omitempty"`
+	Locale                string                 `protobuf:"bytes,13,opt,name=locale,proto3" json:"locale,omitempty"` // Language the account's emails go out in
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -598,6 +708,13 @@ func (x *RegisterUserRequest) GetTurnstileToken() string {
 	return ""
 }
 
+func (x *RegisterUserRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type RegisterUserResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,proto3" json:"user_id,omitempty"`
@@ -954,6 +1071,96 @@ func (x *GetUserProfileResponse) GetIsVerified() bool {
 	return false
 }
 
+// GetUserProfilesRequest looks up several users at once. Unknown IDs are
+// left out of the response; at most 500 IDs are accepted.
+type GetUserProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserProfilesRequest) Reset() {
+	*x = GetUserProfilesRequest{}
+	mi := &file_users_users_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserProfilesRequest) ProtoMessage() {}
+
+func (x *GetUserProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserProfilesRequest.ProtoReflect.Descriptor instead.
+func (*GetUserProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetUserProfilesRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GetUserProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserProfile         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserProfilesResponse) Reset() {
+	*x = GetUserProfilesResponse{}
+	mi := &file_users_users_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserProfilesResponse) ProtoMessage() {}
+
+func (x *GetUserProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserProfilesResponse.ProtoReflect.Descriptor instead.
+func (*GetUserProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetUserProfilesResponse) GetUsers() []*UserProfile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
 type FollowUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
@@ -964,7 +1171,7 @@ type FollowUserRequest struct {
 
 func (x *FollowUserRequest) Reset() {
 	*x = FollowUserRequest{}
-	mi := &file_users_users_proto_msgTypes[15]
+	mi := &file_users_users_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -976,7 +1183,7 @@ func (x *FollowUserRequest) String() string {
 func (*FollowUserRequest) ProtoMessage() {}
 
 func (x *FollowUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[15]
+	mi := &file_users_users_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,7 +1196,7 @@ func (x *FollowUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowUserRequest.ProtoReflect.Descriptor instead.
 func (*FollowUserRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{15}
+	return file_users_users_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *FollowUserRequest) GetFollowerId() string {
@@ -1015,7 +1222,7 @@ type FollowUserResponse struct {
 
 func (x *FollowUserResponse) Reset() {
 	*x = FollowUserResponse{}
-	mi := &file_users_users_proto_msgTypes[16]
+	mi := &file_users_users_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1027,7 +1234,7 @@ func (x *FollowUserResponse) String() string {
 func (*FollowUserResponse) ProtoMessage() {}
 
 func (x *FollowUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[16]
+	mi := &file_users_users_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1040,7 +1247,7 @@ func (x *FollowUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FollowUserResponse.ProtoReflect.Descriptor instead.
 func (*FollowUserResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{16}
+	return file_users_users_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *FollowUserResponse) GetMessage() string {
@@ -1060,7 +1267,7 @@ type UnfollowUserRequest struct {
 
 func (x *UnfollowUserRequest) Reset() {
 	*x = UnfollowUserRequest{}
-	mi := &file_users_users_proto_msgTypes[17]
+	mi := &file_users_users_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1072,7 +1279,7 @@ func (x *UnfollowUserRequest) String() string {
 func (*UnfollowUserRequest) ProtoMessage() {}
 
 func (x *UnfollowUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[17]
+	mi := &file_users_users_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1085,7 +1292,7 @@ func (x *UnfollowUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnfollowUserRequest.ProtoReflect.Descriptor instead.
 func (*UnfollowUserRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{17}
+	return file_users_users_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UnfollowUserRequest) GetFollowerId() string {
@@ -1111,7 +1318,7 @@ type UnfollowUserResponse struct {
 
 func (x *UnfollowUserResponse) Reset() {
 	*x = UnfollowUserResponse{}
-	mi := &file_users_users_proto_msgTypes[18]
+	mi := &file_users_users_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1123,7 +1330,7 @@ func (x *UnfollowUserResponse) String() string {
 func (*UnfollowUserResponse) ProtoMessage() {}
 
 func (x *UnfollowUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[18]
+	mi := &file_users_users_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1136,7 +1343,7 @@ func (x *UnfollowUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnfollowUserResponse.ProtoReflect.Descriptor instead.
 func (*UnfollowUserResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{18}
+	return file_users_users_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *UnfollowUserResponse) GetMessage() string {
@@ -1155,7 +1362,7 @@ type GetFollowingListRequest struct {
 
 func (x *GetFollowingListRequest) Reset() {
 	*x = GetFollowingListRequest{}
-	mi := &file_users_users_proto_msgTypes[19]
+	mi := &file_users_users_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1167,7 +1374,7 @@ func (x *GetFollowingListRequest) String() string {
 func (*GetFollowingListRequest) ProtoMessage() {}
 
 func (x *GetFollowingListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[19]
+	mi := &file_users_users_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1180,7 +1387,7 @@ func (x *GetFollowingListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFollowingListRequest.ProtoReflect.Descriptor instead.
 func (*GetFollowingListRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{19}
+	return file_users_users_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetFollowingListRequest) GetUserId() string {
@@ -1199,7 +1406,7 @@ type GetFollowingListResponse struct {
 
 func (x *GetFollowingListResponse) Reset() {
 	*x = GetFollowingListResponse{}
-	mi := &file_users_users_proto_msgTypes[20]
+	mi := &file_users_users_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1211,7 +1418,7 @@ func (x *GetFollowingListResponse) String() string {
 func (*GetFollowingListResponse) ProtoMessage() {}
 
 func (x *GetFollowingListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[20]
+	mi := &file_users_users_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1224,7 +1431,7 @@ func (x *GetFollowingListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFollowingListResponse.ProtoReflect.Descriptor instead.
 func (*GetFollowingListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{20}
+	return file_users_users_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetFollowingListResponse) GetFollowingIds() []string {
@@ -1250,7 +1457,7 @@ type UserProfile struct {
 
 func (x *UserProfile) Reset() {
 	*x = UserProfile{}
-	mi := &file_users_users_proto_msgTypes[21]
+	mi := &file_users_users_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1262,7 +1469,7 @@ func (x *UserProfile) String() string {
 func (*UserProfile) ProtoMessage() {}
 
 func (x *UserProfile) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[21]
+	mi := &file_users_users_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,7 +1482,7 @@ func (x *UserProfile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
 func (*UserProfile) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{21}
+	return file_users_users_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UserProfile) GetUserId() string {
@@ -1344,7 +1551,7 @@ type SearchUsersRequest struct {
 
 func (x *SearchUsersRequest) Reset() {
 	*x = SearchUsersRequest{}
-	mi := &file_users_users_proto_msgTypes[22]
+	mi := &file_users_users_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1356,7 +1563,7 @@ func (x *SearchUsersRequest) String() string {
 func (*SearchUsersRequest) ProtoMessage() {}
 
 func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[22]
+	mi := &file_users_users_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1369,7 +1576,7 @@ func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
 func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{22}
+	return file_users_users_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *SearchUsersRequest) GetQuery() string {
@@ -1395,7 +1602,7 @@ type SearchUsersResponse struct {
 
 func (x *SearchUsersResponse) Reset() {
 	*x = SearchUsersResponse{}
-	mi := &file_users_users_proto_msgTypes[23]
+	mi := &file_users_users_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1407,7 +1614,7 @@ func (x *SearchUsersResponse) String() string {
 func (*SearchUsersResponse) ProtoMessage() {}
 
 func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[23]
+	mi := &file_users_users_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,7 +1627,7 @@ func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
 func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{23}
+	return file_users_users_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SearchUsersResponse) GetUsers() []*UserProfile {
@@ -1439,7 +1646,7 @@ type GetUserByUsernameRequest struct {
 
 func (x *GetUserByUsernameRequest) Reset() {
 	*x = GetUserByUsernameRequest{}
-	mi := &file_users_users_proto_msgTypes[24]
+	mi := &file_users_users_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1451,7 +1658,7 @@ func (x *GetUserByUsernameRequest) String() string {
 func (*GetUserByUsernameRequest) ProtoMessage() {}
 
 func (x *GetUserByUsernameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[24]
+	mi := &file_users_users_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1464,7 +1671,7 @@ func (x *GetUserByUsernameRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserByUsernameRequest.ProtoReflect.Descriptor instead.
 func (*GetUserByUsernameRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{24}
+	return file_users_users_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetUserByUsernameRequest) GetUsername() string {
@@ -1483,7 +1690,7 @@ type GetSuggestedUsersRequest struct {
 
 func (x *GetSuggestedUsersRequest) Reset() {
 	*x = GetSuggestedUsersRequest{}
-	mi := &file_users_users_proto_msgTypes[25]
+	mi := &file_users_users_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1495,7 +1702,7 @@ func (x *GetSuggestedUsersRequest) String() string {
 func (*GetSuggestedUsersRequest) ProtoMessage() {}
 
 func (x *GetSuggestedUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[25]
+	mi := &file_users_users_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1508,7 +1715,7 @@ func (x *GetSuggestedUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSuggestedUsersRequest.ProtoReflect.Descriptor instead.
 func (*GetSuggestedUsersRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{25}
+	return file_users_users_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetSuggestedUsersRequest) GetUserId() string {
@@ -1527,7 +1734,7 @@ type GetSuggestedUsersResponse struct {
 
 func (x *GetSuggestedUsersResponse) Reset() {
 	*x = GetSuggestedUsersResponse{}
-	mi := &file_users_users_proto_msgTypes[26]
+	mi := &file_users_users_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1539,7 +1746,7 @@ func (x *GetSuggestedUsersResponse) String() string {
 func (*GetSuggestedUsersResponse) ProtoMessage() {}
 
 func (x *GetSuggestedUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[26]
+	mi := &file_users_users_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,7 +1759,7 @@ func (x *GetSuggestedUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSuggestedUsersResponse.ProtoReflect.Descriptor instead.
 func (*GetSuggestedUsersResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{26}
+	return file_users_users_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetSuggestedUsersResponse) GetUsers() []*UserProfile {
@@ -1571,7 +1778,7 @@ type GetFollowingProfilesResponse struct {
 
 func (x *GetFollowingProfilesResponse) Reset() {
 	*x = GetFollowingProfilesResponse{}
-	mi := &file_users_users_proto_msgTypes[27]
+	mi := &file_users_users_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1583,7 +1790,7 @@ func (x *GetFollowingProfilesResponse) String() string {
 func (*GetFollowingProfilesResponse) ProtoMessage() {}
 
 func (x *GetFollowingProfilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[27]
+	mi := &file_users_users_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1596,7 +1803,7 @@ func (x *GetFollowingProfilesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFollowingProfilesResponse.ProtoReflect.Descriptor instead.
 func (*GetFollowingProfilesResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{27}
+	return file_users_users_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetFollowingProfilesResponse) GetUsers() []*UserProfile {
@@ -1616,7 +1823,7 @@ type BlockUserRequest struct {
 
 func (x *BlockUserRequest) Reset() {
 	*x = BlockUserRequest{}
-	mi := &file_users_users_proto_msgTypes[28]
+	mi := &file_users_users_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1628,7 +1835,7 @@ func (x *BlockUserRequest) String() string {
 func (*BlockUserRequest) ProtoMessage() {}
 
 func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[28]
+	mi := &file_users_users_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1641,7 +1848,7 @@ func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
 func (*BlockUserRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{28}
+	return file_users_users_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *BlockUserRequest) GetBlockerId() string {
@@ -1667,7 +1874,7 @@ type BlockUserResponse struct {
 
 func (x *BlockUserResponse) Reset() {
 	*x = BlockUserResponse{}
-	mi := &file_users_users_proto_msgTypes[29]
+	mi := &file_users_users_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1679,7 +1886,7 @@ func (x *BlockUserResponse) String() string {
 func (*BlockUserResponse) ProtoMessage() {}
 
 func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[29]
+	mi := &file_users_users_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1692,7 +1899,7 @@ func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
 func (*BlockUserResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{29}
+	return file_users_users_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *BlockUserResponse) GetMessage() string {
@@ -1712,7 +1919,7 @@ type UnblockUserRequest struct {
 
 func (x *UnblockUserRequest) Reset() {
 	*x = UnblockUserRequest{}
-	mi := &file_users_users_proto_msgTypes[30]
+	mi := &file_users_users_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1724,7 +1931,7 @@ func (x *UnblockUserRequest) String() string {
 func (*UnblockUserRequest) ProtoMessage() {}
 
 func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[30]
+	mi := &file_users_users_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,7 +1944,7 @@ func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
 func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{30}
+	return file_users_users_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *UnblockUserRequest) GetBlockerId() string {
@@ -1763,7 +1970,7 @@ type UnblockUserResponse struct {
 
 func (x *UnblockUserResponse) Reset() {
 	*x = UnblockUserResponse{}
-	mi := &file_users_users_proto_msgTypes[31]
+	mi := &file_users_users_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1775,7 +1982,7 @@ func (x *UnblockUserResponse) String() string {
 func (*UnblockUserResponse) ProtoMessage() {}
 
 func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[31]
+	mi := &file_users_users_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1788,7 +1995,7 @@ func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
 func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{31}
+	return file_users_users_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *UnblockUserResponse) GetMessage() string {
@@ -1807,7 +2014,7 @@ type GetBlockedListRequest struct {
 
 func (x *GetBlockedListRequest) Reset() {
 	*x = GetBlockedListRequest{}
-	mi := &file_users_users_proto_msgTypes[32]
+	mi := &file_users_users_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1819,7 +2026,7 @@ func (x *GetBlockedListRequest) String() string {
 func (*GetBlockedListRequest) ProtoMessage() {}
 
 func (x *GetBlockedListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[32]
+	mi := &file_users_users_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1832,7 +2039,7 @@ func (x *GetBlockedListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockedListRequest.ProtoReflect.Descriptor instead.
 func (*GetBlockedListRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{32}
+	return file_users_users_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetBlockedListRequest) GetUserId() string {
@@ -1851,7 +2058,7 @@ type GetBlockedListResponse struct {
 
 func (x *GetBlockedListResponse) Reset() {
 	*x = GetBlockedListResponse{}
-	mi := &file_users_users_proto_msgTypes[33]
+	mi := &file_users_users_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1863,7 +2070,7 @@ func (x *GetBlockedListResponse) String() string {
 func (*GetBlockedListResponse) ProtoMessage() {}
 
 func (x *GetBlockedListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[33]
+	mi := &file_users_users_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1876,7 +2083,7 @@ func (x *GetBlockedListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBlockedListResponse.ProtoReflect.Descriptor instead.
 func (*GetBlockedListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{33}
+	return file_users_users_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetBlockedListResponse) GetUsers() []*UserProfile {
@@ -1886,32 +2093,336 @@ func (x *GetBlockedListResponse) GetUsers() []*UserProfile {
 	return nil
 }
 
-type UpdateUserProfileRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Bio               string                 `protobuf:"bytes,3,opt,name=bio,proto3" json:"bio,omitempty"`
-	Gender            string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
-	ProfilePictureUrl string                 `protobuf:"bytes,5,opt,name=profile_picture_url,json=profilePictureUrl,proto3" json:"profile_picture_url,omitempty"` // Optional
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type IsBlockedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserProfileRequest) Reset() {
-	*x = UpdateUserProfileRequest{}
-	mi := &file_users_users_proto_msgTypes[34]
+func (x *IsBlockedRequest) Reset() {
+	*x = IsBlockedRequest{}
+	mi := &file_users_users_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserProfileRequest) String() string {
+func (x *IsBlockedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserProfileRequest) ProtoMessage() {}
+func (*IsBlockedRequest) ProtoMessage() {}
 
-func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[34]
+func (x *IsBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsBlockedRequest.ProtoReflect.Descriptor instead.
+func (*IsBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *IsBlockedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IsBlockedRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type IsBlockedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsBlocked     bool                   `protobuf:"varint,1,opt,name=is_blocked,json=isBlocked,proto3" json:"is_blocked,omitempty"` // true if either user blocked the other
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsBlockedResponse) Reset() {
+	*x = IsBlockedResponse{}
+	mi := &file_users_users_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsBlockedResponse) ProtoMessage() {}
+
+func (x *IsBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsBlockedResponse.ProtoReflect.Descriptor instead.
+func (*IsBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *IsBlockedResponse) GetIsBlocked() bool {
+	if x != nil {
+		return x.IsBlocked
+	}
+	return false
+}
+
+type GetBlockRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRelationsRequest) Reset() {
+	*x = GetBlockRelationsRequest{}
+	mi := &file_users_users_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRelationsRequest) ProtoMessage() {}
+
+func (x *GetBlockRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRelationsRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetBlockRelationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBlockRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockedIds    []string               `protobuf:"bytes,1,rep,name=blocked_ids,json=blockedIds,proto3" json:"blocked_ids,omitempty"`         // users this user blocked
+	BlockedByIds  []string               `protobuf:"bytes,2,rep,name=blocked_by_ids,json=blockedByIds,proto3" json:"blocked_by_ids,omitempty"` // users who blocked this user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRelationsResponse) Reset() {
+	*x = GetBlockRelationsResponse{}
+	mi := &file_users_users_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRelationsResponse) ProtoMessage() {}
+
+func (x *GetBlockRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRelationsResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetBlockRelationsResponse) GetBlockedIds() []string {
+	if x != nil {
+		return x.BlockedIds
+	}
+	return nil
+}
+
+func (x *GetBlockRelationsResponse) GetBlockedByIds() []string {
+	if x != nil {
+		return x.BlockedByIds
+	}
+	return nil
+}
+
+type GetRelationshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelationshipRequest) Reset() {
+	*x = GetRelationshipRequest{}
+	mi := &file_users_users_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipRequest) ProtoMessage() {}
+
+func (x *GetRelationshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipRequest.ProtoReflect.Descriptor instead.
+func (*GetRelationshipRequest) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetRelationshipRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRelationshipRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type GetRelationshipResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IsFollowing     bool                   `protobuf:"varint,1,opt,name=is_following,json=isFollowing,proto3" json:"is_following,omitempty"`      // user follows target
+	IsFollowedBy    bool                   `protobuf:"varint,2,opt,name=is_followed_by,json=isFollowedBy,proto3" json:"is_followed_by,omitempty"` // target follows user
+	TargetIsPrivate bool                   `protobuf:"varint,3,opt,name=target_is_private,json=targetIsPrivate,proto3" json:"target_is_private,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRelationshipResponse) Reset() {
+	*x = GetRelationshipResponse{}
+	mi := &file_users_users_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelationshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelationshipResponse) ProtoMessage() {}
+
+func (x *GetRelationshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelationshipResponse.ProtoReflect.Descriptor instead.
+func (*GetRelationshipResponse) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetRelationshipResponse) GetIsFollowing() bool {
+	if x != nil {
+		return x.IsFollowing
+	}
+	return false
+}
+
+func (x *GetRelationshipResponse) GetIsFollowedBy() bool {
+	if x != nil {
+		return x.IsFollowedBy
+	}
+	return false
+}
+
+func (x *GetRelationshipResponse) GetTargetIsPrivate() bool {
+	if x != nil {
+		return x.TargetIsPrivate
+	}
+	return false
+}
+
+type UpdateUserProfileRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Bio               string                 `protobuf:"bytes,3,opt,name=bio,proto3" json:"bio,omitempty"`
+	Gender            string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
+	ProfilePictureUrl string                 `protobuf:"bytes,5,opt,name=profile_picture_url,json=profilePictureUrl,proto3" json:"profile_picture_url,omitempty"` // Optional
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateUserProfileRequest) Reset() {
+	*x = UpdateUserProfileRequest{}
+	mi := &file_users_users_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserProfileRequest) ProtoMessage() {}
+
+func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1924,7 +2435,7 @@ func (x *UpdateUserProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserProfileRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserProfileRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{34}
+	return file_users_users_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *UpdateUserProfileRequest) GetUserId() string {
@@ -1971,7 +2482,7 @@ type UpdateUserProfileResponse struct {
 
 func (x *UpdateUserProfileResponse) Reset() {
 	*x = UpdateUserProfileResponse{}
-	mi := &file_users_users_proto_msgTypes[35]
+	mi := &file_users_users_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1983,7 +2494,7 @@ func (x *UpdateUserProfileResponse) String() string {
 func (*UpdateUserProfileResponse) ProtoMessage() {}
 
 func (x *UpdateUserProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[35]
+	mi := &file_users_users_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1996,7 +2507,7 @@ func (x *UpdateUserProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserProfileResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserProfileResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{35}
+	return file_users_users_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *UpdateUserProfileResponse) GetUser() *UserProfile {
@@ -2006,18 +2517,116 @@ func (x *UpdateUserProfileResponse) GetUser() *UserProfile {
 	return nil
 }
 
-type UpdateNotificationSettingsRequest struct {
+// NotificationPreferences picks a channel per kind of notification. Story
+// likes count as likes.
+type NotificationPreferences struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	EnablePush    bool                   `protobuf:"varint,2,opt,name=enable_push,json=enablePush,proto3" json:"enable_push,omitempty"`
-	EnableEmail   bool                   `protobuf:"varint,3,opt,name=enable_email,json=enableEmail,proto3" json:"enable_email,omitempty"`
+	Likes         NotificationChannel    `protobuf:"varint,1,opt,name=likes,proto3,enum=users.NotificationChannel" json:"likes,omitempty"`
+	Comments      NotificationChannel    `protobuf:"varint,2,opt,name=comments,proto3,enum=users.NotificationChannel" json:"comments,omitempty"`
+	Follows       NotificationChannel    `protobuf:"varint,3,opt,name=follows,proto3,enum=users.NotificationChannel" json:"follows,omitempty"`
+	Mentions      NotificationChannel    `protobuf:"varint,4,opt,name=mentions,proto3,enum=users.NotificationChannel" json:"mentions,omitempty"`
+	StoryReplies  NotificationChannel    `protobuf:"varint,5,opt,name=story_replies,json=storyReplies,proto3,enum=users.NotificationChannel" json:"story_replies,omitempty"`
+	Messages      NotificationChannel    `protobuf:"varint,6,opt,name=messages,proto3,enum=users.NotificationChannel" json:"messages,omitempty"`
+	Digest        DigestFrequency        `protobuf:"varint,7,opt,name=digest,proto3,enum=users.DigestFrequency" json:"digest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_users_users_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_users_users_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_users_users_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *NotificationPreferences) GetLikes() NotificationChannel {
+	if x != nil {
+		return x.Likes
+	}
+	return NotificationChannel_CHANNEL_IN_APP
+}
+
+func (x *NotificationPreferences) GetComments() NotificationChannel {
+	if x != nil {
+		return x.Comments
+	}
+	return NotificationChannel_CHANNEL_IN_APP
+}
+
+func (x *NotificationPreferences) GetFollows() NotificationChannel {
+	if x != nil {
+		return x.Follows
+	}
+	return NotificationChannel_CHANNEL_IN_APP
+}
+
+func (x *NotificationPreferences) GetMentions() NotificationChannel {
+	if x != nil {
+		return x.Mentions
+	}
+	return NotificationChannel_CHANNEL_IN_APP
+}
+
+func (x *NotificationPreferences) GetStoryReplies() NotificationChannel {
+	if x != nil {
+		return x.StoryReplies
+	}
+	return NotificationChannel_CHANNEL_IN_APP
+}
+
+func (x *NotificationPreferences) GetMessages() NotificationChannel {
+	if x != nil {
+		return x.Messages
+	}
+	return NotificationChannel_CHANNEL_IN_APP
+}
+
+func (x *NotificationPreferences) GetDigest() DigestFrequency {
+	if x != nil {
+		return x.Digest
+	}
+	return DigestFrequency_DIGEST_OFF
+}
+
+type UpdateNotificationSettingsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EnablePush  bool                   `protobuf:"varint,2,opt,name=enable_push,json=enablePush,proto3" json:"enable_push,omitempty"`
+	EnableEmail bool                   `protobuf:"varint,3,opt,name=enable_email,json=enableEmail,proto3" json:"enable_email,omitempty"`
+	// Replaces the stored preferences when set; left alone otherwise.
+	Preferences *NotificationPreferences `protobuf:"bytes,4,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	// Language of emails, e.g. "id"; left alone when empty.
+	Locale        string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateNotificationSettingsRequest) Reset() {
 	*x = UpdateNotificationSettingsRequest{}
-	mi := &file_users_users_proto_msgTypes[36]
+	mi := &file_users_users_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2029,7 +2638,7 @@ func (x *UpdateNotificationSettingsRequest) String() string {
 func (*UpdateNotificationSettingsRequest) ProtoMessage() {}
 
 func (x *UpdateNotificationSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[36]
+	mi := &file_users_users_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2042,7 +2651,7 @@ func (x *UpdateNotificationSettingsRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use UpdateNotificationSettingsRequest.ProtoReflect.Descriptor instead.
 func (*UpdateNotificationSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{36}
+	return file_users_users_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *UpdateNotificationSettingsRequest) GetUserId() string {
@@ -2066,6 +2675,20 @@ func (x *UpdateNotificationSettingsRequest) GetEnableEmail() bool {
 	return false
 }
 
+func (x *UpdateNotificationSettingsRequest) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *UpdateNotificationSettingsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type UpdateNotificationSettingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -2075,7 +2698,7 @@ type UpdateNotificationSettingsResponse struct {
 
 func (x *UpdateNotificationSettingsResponse) Reset() {
 	*x = UpdateNotificationSettingsResponse{}
-	mi := &file_users_users_proto_msgTypes[37]
+	mi := &file_users_users_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2087,7 +2710,7 @@ func (x *UpdateNotificationSettingsResponse) String() string {
 func (*UpdateNotificationSettingsResponse) ProtoMessage() {}
 
 func (x *UpdateNotificationSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[37]
+	mi := &file_users_users_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2100,7 +2723,7 @@ func (x *UpdateNotificationSettingsResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use UpdateNotificationSettingsResponse.ProtoReflect.Descriptor instead.
 func (*UpdateNotificationSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{37}
+	return file_users_users_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *UpdateNotificationSettingsResponse) GetSuccess() bool {
@@ -2120,7 +2743,7 @@ type UpdatePrivacySettingsRequest struct {
 
 func (x *UpdatePrivacySettingsRequest) Reset() {
 	*x = UpdatePrivacySettingsRequest{}
-	mi := &file_users_users_proto_msgTypes[38]
+	mi := &file_users_users_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2132,7 +2755,7 @@ func (x *UpdatePrivacySettingsRequest) String() string {
 func (*UpdatePrivacySettingsRequest) ProtoMessage() {}
 
 func (x *UpdatePrivacySettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[38]
+	mi := &file_users_users_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2145,7 +2768,7 @@ func (x *UpdatePrivacySettingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePrivacySettingsRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePrivacySettingsRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{38}
+	return file_users_users_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *UpdatePrivacySettingsRequest) GetUserId() string {
@@ -2172,7 +2795,7 @@ type UpdatePrivacySettingsResponse struct {
 
 func (x *UpdatePrivacySettingsResponse) Reset() {
 	*x = UpdatePrivacySettingsResponse{}
-	mi := &file_users_users_proto_msgTypes[39]
+	mi := &file_users_users_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2184,7 +2807,7 @@ func (x *UpdatePrivacySettingsResponse) String() string {
 func (*UpdatePrivacySettingsResponse) ProtoMessage() {}
 
 func (x *UpdatePrivacySettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[39]
+	mi := &file_users_users_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2197,7 +2820,7 @@ func (x *UpdatePrivacySettingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePrivacySettingsResponse.ProtoReflect.Descriptor instead.
 func (*UpdatePrivacySettingsResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{39}
+	return file_users_users_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *UpdatePrivacySettingsResponse) GetSuccess() bool {
@@ -2223,7 +2846,7 @@ type GetSettingsRequest struct {
 
 func (x *GetSettingsRequest) Reset() {
 	*x = GetSettingsRequest{}
-	mi := &file_users_users_proto_msgTypes[40]
+	mi := &file_users_users_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2235,7 +2858,7 @@ func (x *GetSettingsRequest) String() string {
 func (*GetSettingsRequest) ProtoMessage() {}
 
 func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[40]
+	mi := &file_users_users_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2248,7 +2871,7 @@ func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSettingsRequest.ProtoReflect.Descriptor instead.
 func (*GetSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{40}
+	return file_users_users_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *GetSettingsRequest) GetUserId() string {
@@ -2259,17 +2882,19 @@ func (x *GetSettingsRequest) GetUserId() string {
 }
 
 type GetSettingsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EnablePush    bool                   `protobuf:"varint,1,opt,name=enable_push,json=enablePush,proto3" json:"enable_push,omitempty"`
-	EnableEmail   bool                   `protobuf:"varint,2,opt,name=enable_email,json=enableEmail,proto3" json:"enable_email,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,3,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                   protoimpl.MessageState   `protogen:"open.v1"`
+	EnablePush              bool                     `protobuf:"varint,1,opt,name=enable_push,json=enablePush,proto3" json:"enable_push,omitempty"`
+	EnableEmail             bool                     `protobuf:"varint,2,opt,name=enable_email,json=enableEmail,proto3" json:"enable_email,omitempty"`
+	IsPrivate               bool                     `protobuf:"varint,3,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	NotificationPreferences *NotificationPreferences `protobuf:"bytes,4,opt,name=notification_preferences,json=notificationPreferences,proto3" json:"notification_preferences,omitempty"`
+	Locale                  string                   `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *GetSettingsResponse) Reset() {
 	*x = GetSettingsResponse{}
-	mi := &file_users_users_proto_msgTypes[41]
+	mi := &file_users_users_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2281,7 +2906,7 @@ func (x *GetSettingsResponse) String() string {
 func (*GetSettingsResponse) ProtoMessage() {}
 
 func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[41]
+	mi := &file_users_users_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2294,7 +2919,7 @@ func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSettingsResponse.ProtoReflect.Descriptor instead.
 func (*GetSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{41}
+	return file_users_users_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *GetSettingsResponse) GetEnablePush() bool {
@@ -2318,6 +2943,20 @@ func (x *GetSettingsResponse) GetIsPrivate() bool {
 	return false
 }
 
+func (x *GetSettingsResponse) GetNotificationPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.NotificationPreferences
+	}
+	return nil
+}
+
+func (x *GetSettingsResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 type ManageRelationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -2328,7 +2967,7 @@ type ManageRelationRequest struct {
 
 func (x *ManageRelationRequest) Reset() {
 	*x = ManageRelationRequest{}
-	mi := &file_users_users_proto_msgTypes[42]
+	mi := &file_users_users_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2340,7 +2979,7 @@ func (x *ManageRelationRequest) String() string {
 func (*ManageRelationRequest) ProtoMessage() {}
 
 func (x *ManageRelationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[42]
+	mi := &file_users_users_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2353,7 +2992,7 @@ func (x *ManageRelationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ManageRelationRequest.ProtoReflect.Descriptor instead.
 func (*ManageRelationRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{42}
+	return file_users_users_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *ManageRelationRequest) GetUserId() string {
@@ -2379,7 +3018,7 @@ type ManageRelationResponse struct {
 
 func (x *ManageRelationResponse) Reset() {
 	*x = ManageRelationResponse{}
-	mi := &file_users_users_proto_msgTypes[43]
+	mi := &file_users_users_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2391,7 +3030,7 @@ func (x *ManageRelationResponse) String() string {
 func (*ManageRelationResponse) ProtoMessage() {}
 
 func (x *ManageRelationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[43]
+	mi := &file_users_users_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2404,7 +3043,7 @@ func (x *ManageRelationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ManageRelationResponse.ProtoReflect.Descriptor instead.
 func (*ManageRelationResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{43}
+	return file_users_users_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *ManageRelationResponse) GetSuccess() bool {
@@ -2423,7 +3062,7 @@ type GetListRequest struct {
 
 func (x *GetListRequest) Reset() {
 	*x = GetListRequest{}
-	mi := &file_users_users_proto_msgTypes[44]
+	mi := &file_users_users_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2435,7 +3074,7 @@ func (x *GetListRequest) String() string {
 func (*GetListRequest) ProtoMessage() {}
 
 func (x *GetListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[44]
+	mi := &file_users_users_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2448,7 +3087,7 @@ func (x *GetListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetListRequest.ProtoReflect.Descriptor instead.
 func (*GetListRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{44}
+	return file_users_users_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *GetListRequest) GetUserId() string {
@@ -2467,7 +3106,7 @@ type GetListResponse struct {
 
 func (x *GetListResponse) Reset() {
 	*x = GetListResponse{}
-	mi := &file_users_users_proto_msgTypes[45]
+	mi := &file_users_users_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2479,7 +3118,7 @@ func (x *GetListResponse) String() string {
 func (*GetListResponse) ProtoMessage() {}
 
 func (x *GetListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[45]
+	mi := &file_users_users_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2492,7 +3131,7 @@ func (x *GetListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetListResponse.ProtoReflect.Descriptor instead.
 func (*GetListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{45}
+	return file_users_users_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *GetListResponse) GetUsers() []*UserProfile {
@@ -2514,7 +3153,7 @@ type RequestVerificationRequest struct {
 
 func (x *RequestVerificationRequest) Reset() {
 	*x = RequestVerificationRequest{}
-	mi := &file_users_users_proto_msgTypes[46]
+	mi := &file_users_users_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2526,7 +3165,7 @@ func (x *RequestVerificationRequest) String() string {
 func (*RequestVerificationRequest) ProtoMessage() {}
 
 func (x *RequestVerificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[46]
+	mi := &file_users_users_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2539,7 +3178,7 @@ func (x *RequestVerificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestVerificationRequest.ProtoReflect.Descriptor instead.
 func (*RequestVerificationRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{46}
+	return file_users_users_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *RequestVerificationRequest) GetUserId() string {
@@ -2579,7 +3218,7 @@ type RequestVerificationResponse struct {
 
 func (x *RequestVerificationResponse) Reset() {
 	*x = RequestVerificationResponse{}
-	mi := &file_users_users_proto_msgTypes[47]
+	mi := &file_users_users_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2591,7 +3230,7 @@ func (x *RequestVerificationResponse) String() string {
 func (*RequestVerificationResponse) ProtoMessage() {}
 
 func (x *RequestVerificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[47]
+	mi := &file_users_users_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2604,7 +3243,7 @@ func (x *RequestVerificationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestVerificationResponse.ProtoReflect.Descriptor instead.
 func (*RequestVerificationResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{47}
+	return file_users_users_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *RequestVerificationResponse) GetMessage() string {
@@ -2622,7 +3261,7 @@ type Empty struct {
 
 func (x *Empty) Reset() {
 	*x = Empty{}
-	mi := &file_users_users_proto_msgTypes[48]
+	mi := &file_users_users_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2634,7 +3273,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[48]
+	mi := &file_users_users_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2647,7 +3286,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{48}
+	return file_users_users_proto_rawDescGZIP(), []int{57}
 }
 
 type UserListResponse struct {
@@ -2659,7 +3298,7 @@ type UserListResponse struct {
 
 func (x *UserListResponse) Reset() {
 	*x = UserListResponse{}
-	mi := &file_users_users_proto_msgTypes[49]
+	mi := &file_users_users_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2671,7 +3310,7 @@ func (x *UserListResponse) String() string {
 func (*UserListResponse) ProtoMessage() {}
 
 func (x *UserListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[49]
+	mi := &file_users_users_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2684,7 +3323,7 @@ func (x *UserListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserListResponse.ProtoReflect.Descriptor instead.
 func (*UserListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{49}
+	return file_users_users_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *UserListResponse) GetUsers() []*UserProfile {
@@ -2704,7 +3343,7 @@ type ToggleUserBanRequest struct {
 
 func (x *ToggleUserBanRequest) Reset() {
 	*x = ToggleUserBanRequest{}
-	mi := &file_users_users_proto_msgTypes[50]
+	mi := &file_users_users_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2716,7 +3355,7 @@ func (x *ToggleUserBanRequest) String() string {
 func (*ToggleUserBanRequest) ProtoMessage() {}
 
 func (x *ToggleUserBanRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[50]
+	mi := &file_users_users_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2729,7 +3368,7 @@ func (x *ToggleUserBanRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToggleUserBanRequest.ProtoReflect.Descriptor instead.
 func (*ToggleUserBanRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{50}
+	return file_users_users_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *ToggleUserBanRequest) GetUserId() string {
@@ -2755,7 +3394,7 @@ type EmailListResponse struct {
 
 func (x *EmailListResponse) Reset() {
 	*x = EmailListResponse{}
-	mi := &file_users_users_proto_msgTypes[51]
+	mi := &file_users_users_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2767,7 +3406,7 @@ func (x *EmailListResponse) String() string {
 func (*EmailListResponse) ProtoMessage() {}
 
 func (x *EmailListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[51]
+	mi := &file_users_users_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2780,7 +3419,7 @@ func (x *EmailListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmailListResponse.ProtoReflect.Descriptor instead.
 func (*EmailListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{51}
+	return file_users_users_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *EmailListResponse) GetEmails() []string {
@@ -2807,7 +3446,7 @@ type VerificationRequestItem struct {
 
 func (x *VerificationRequestItem) Reset() {
 	*x = VerificationRequestItem{}
-	mi := &file_users_users_proto_msgTypes[52]
+	mi := &file_users_users_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2819,7 +3458,7 @@ func (x *VerificationRequestItem) String() string {
 func (*VerificationRequestItem) ProtoMessage() {}
 
 func (x *VerificationRequestItem) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[52]
+	mi := &file_users_users_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2832,7 +3471,7 @@ func (x *VerificationRequestItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerificationRequestItem.ProtoReflect.Descriptor instead.
 func (*VerificationRequestItem) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{52}
+	return file_users_users_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *VerificationRequestItem) GetId() string {
@@ -2907,7 +3546,7 @@ type VerificationListResponse struct {
 
 func (x *VerificationListResponse) Reset() {
 	*x = VerificationListResponse{}
-	mi := &file_users_users_proto_msgTypes[53]
+	mi := &file_users_users_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2919,7 +3558,7 @@ func (x *VerificationListResponse) String() string {
 func (*VerificationListResponse) ProtoMessage() {}
 
 func (x *VerificationListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[53]
+	mi := &file_users_users_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2932,7 +3571,7 @@ func (x *VerificationListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerificationListResponse.ProtoReflect.Descriptor instead.
 func (*VerificationListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{53}
+	return file_users_users_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *VerificationListResponse) GetRequests() []*VerificationRequestItem {
@@ -2952,7 +3591,7 @@ type ReviewVerificationRequest struct {
 
 func (x *ReviewVerificationRequest) Reset() {
 	*x = ReviewVerificationRequest{}
-	mi := &file_users_users_proto_msgTypes[54]
+	mi := &file_users_users_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2964,7 +3603,7 @@ func (x *ReviewVerificationRequest) String() string {
 func (*ReviewVerificationRequest) ProtoMessage() {}
 
 func (x *ReviewVerificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[54]
+	mi := &file_users_users_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2977,7 +3616,7 @@ func (x *ReviewVerificationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReviewVerificationRequest.ProtoReflect.Descriptor instead.
 func (*ReviewVerificationRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{54}
+	return file_users_users_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *ReviewVerificationRequest) GetRequestId() string {
@@ -3004,7 +3643,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_users_users_proto_msgTypes[55]
+	mi := &file_users_users_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3016,7 +3655,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[55]
+	mi := &file_users_users_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3029,7 +3668,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{55}
+	return file_users_users_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *Response) GetMessage() string {
@@ -3062,7 +3701,7 @@ type UserReportItem struct {
 
 func (x *UserReportItem) Reset() {
 	*x = UserReportItem{}
-	mi := &file_users_users_proto_msgTypes[56]
+	mi := &file_users_users_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3074,7 +3713,7 @@ func (x *UserReportItem) String() string {
 func (*UserReportItem) ProtoMessage() {}
 
 func (x *UserReportItem) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[56]
+	mi := &file_users_users_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3087,7 +3726,7 @@ func (x *UserReportItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserReportItem.ProtoReflect.Descriptor instead.
 func (*UserReportItem) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{56}
+	return file_users_users_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *UserReportItem) GetId() string {
@@ -3155,7 +3794,7 @@ type UserReportListResponse struct {
 
 func (x *UserReportListResponse) Reset() {
 	*x = UserReportListResponse{}
-	mi := &file_users_users_proto_msgTypes[57]
+	mi := &file_users_users_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3167,7 +3806,7 @@ func (x *UserReportListResponse) String() string {
 func (*UserReportListResponse) ProtoMessage() {}
 
 func (x *UserReportListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[57]
+	mi := &file_users_users_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3180,7 +3819,7 @@ func (x *UserReportListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserReportListResponse.ProtoReflect.Descriptor instead.
 func (*UserReportListResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{57}
+	return file_users_users_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *UserReportListResponse) GetReports() []*UserReportItem {
@@ -3200,7 +3839,7 @@ type ReviewReportRequest struct {
 
 func (x *ReviewReportRequest) Reset() {
 	*x = ReviewReportRequest{}
-	mi := &file_users_users_proto_msgTypes[58]
+	mi := &file_users_users_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3212,7 +3851,7 @@ func (x *ReviewReportRequest) String() string {
 func (*ReviewReportRequest) ProtoMessage() {}
 
 func (x *ReviewReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[58]
+	mi := &file_users_users_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3225,7 +3864,7 @@ func (x *ReviewReportRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReviewReportRequest.ProtoReflect.Descriptor instead.
 func (*ReviewReportRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{58}
+	return file_users_users_proto_rawDescGZIP(), []int{67}
 }
 
 func (x *ReviewReportRequest) GetReportId() string {
@@ -3253,7 +3892,7 @@ type ReportUserRequest struct {
 
 func (x *ReportUserRequest) Reset() {
 	*x = ReportUserRequest{}
-	mi := &file_users_users_proto_msgTypes[59]
+	mi := &file_users_users_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3265,7 +3904,7 @@ func (x *ReportUserRequest) String() string {
 func (*ReportUserRequest) ProtoMessage() {}
 
 func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[59]
+	mi := &file_users_users_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3278,7 +3917,7 @@ func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportUserRequest.ProtoReflect.Descriptor instead.
 func (*ReportUserRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{59}
+	return file_users_users_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *ReportUserRequest) GetReportedUserId() string {
@@ -3311,7 +3950,7 @@ type GetUserEmailRequest struct {
 
 func (x *GetUserEmailRequest) Reset() {
 	*x = GetUserEmailRequest{}
-	mi := &file_users_users_proto_msgTypes[60]
+	mi := &file_users_users_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3323,7 +3962,7 @@ func (x *GetUserEmailRequest) String() string {
 func (*GetUserEmailRequest) ProtoMessage() {}
 
 func (x *GetUserEmailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[60]
+	mi := &file_users_users_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3336,7 +3975,7 @@ func (x *GetUserEmailRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserEmailRequest.ProtoReflect.Descriptor instead.
 func (*GetUserEmailRequest) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{60}
+	return file_users_users_proto_rawDescGZIP(), []int{69}
 }
 
 func (x *GetUserEmailRequest) GetUserId() string {
@@ -3349,13 +3988,14 @@ func (x *GetUserEmailRequest) GetUserId() string {
 type GetUserEmailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserEmailResponse) Reset() {
 	*x = GetUserEmailResponse{}
-	mi := &file_users_users_proto_msgTypes[61]
+	mi := &file_users_users_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3367,7 +4007,7 @@ func (x *GetUserEmailResponse) String() string {
 func (*GetUserEmailResponse) ProtoMessage() {}
 
 func (x *GetUserEmailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_users_users_proto_msgTypes[61]
+	mi := &file_users_users_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3380,7 +4020,7 @@ func (x *GetUserEmailResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserEmailResponse.ProtoReflect.Descriptor instead.
 func (*GetUserEmailResponse) Descriptor() ([]byte, []int) {
-	return file_users_users_proto_rawDescGZIP(), []int{61}
+	return file_users_users_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *GetUserEmailResponse) GetEmail() string {
@@ -3390,6 +4030,13 @@ func (x *GetUserEmailResponse) GetEmail() string {
 	return ""
 }
 
+func (x *GetUserEmailResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
 var File_users_users_proto protoreflect.FileDescriptor
 
 const file_users_users_proto_rawDesc = "" +
@@ -3408,9 +4055,10 @@ const file_users_users_proto_rawDesc = "" +
 	"\x06tokens\x18\x02 \x01(\v2\x14.users.TokenResponseR\x06tokens\"I\n" +
 	"\x15VerifyLogin2FARequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\botp_code\x18\x02 \x01(\tR\botp_code\"&\n" +
+	"\botp_code\x18\x02 \x01(\tR\botp_code\">\n" +
 	"\x0eSendOtpRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"+\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"+\n" +
 	"\x0fSendOtpResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"3\n" +
 	"\x1bRequestPasswordResetRequest\x12\x14\n" +
@@ -3418,7 +4066,7 @@ const file_users_users_proto_rawDesc = "" +
 	"\x1bPerformPasswordResetRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\"\n" +
 	"\fnew_password\x18\x02 \x01(\tR\fnew_password\x12*\n" +
-	"\x10confirm_password\x18\x03 \x01(\tR\x10confirm_password\"\xcf\x03\n" +
+	"\x10confirm_password\x18\x03 \x01(\tR\x10confirm_password\"\xe7\x03\n" +
 	"\x13RegisterUserRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -3434,7 +4082,8 @@ const file_users_users_proto_rawDesc = "" +
 	" \x01(\bR\n" +
 	"enable_2fa\x12\x1a\n" +
 	"\botp_code\x18\v \x01(\tR\botp_code\x12(\n" +
-	"\x0fturnstile_token\x18\f \x01(\tR\x0fturnstile_token\"\x82\x02\n" +
+	"\x0fturnstile_token\x18\f \x01(\tR\x0fturnstile_token\x12\x16\n" +
+	"\x06locale\x18\r \x01(\tR\x06locale\"\x82\x02\n" +
 	"\x14RegisterUserResponse\x12\x18\n" +
 	"\auser_id\x18\x01 \x01(\tR\auser_id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -3462,7 +4111,11 @@ const file_users_users_proto_rawDesc = "" +
 	"\x0ffollowing_count\x18\a \x01(\x03R\x0efollowingCount\x12!\n" +
 	"\fis_following\x18\b \x01(\bR\visFollowing\x12\x1f\n" +
 	"\vis_verified\x18\t \x01(\bR\n" +
-	"isVerified\"W\n" +
+	"isVerified\"3\n" +
+	"\x16GetUserProfilesRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"C\n" +
+	"\x17GetUserProfilesResponse\x12(\n" +
+	"\x05users\x18\x01 \x03(\v2\x12.users.UserProfileR\x05users\"W\n" +
 	"\x11FollowUserRequest\x12\x1f\n" +
 	"\vfollower_id\x18\x01 \x01(\tR\n" +
 	"followerId\x12!\n" +
@@ -3519,7 +4172,26 @@ const file_users_users_proto_rawDesc = "" +
 	"\x15GetBlockedListRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
 	"\x16GetBlockedListResponse\x12(\n" +
-	"\x05users\x18\x01 \x03(\v2\x12.users.UserProfileR\x05users\"\xa1\x01\n" +
+	"\x05users\x18\x01 \x03(\v2\x12.users.UserProfileR\x05users\"H\n" +
+	"\x10IsBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"2\n" +
+	"\x11IsBlockedResponse\x12\x1d\n" +
+	"\n" +
+	"is_blocked\x18\x01 \x01(\bR\tisBlocked\"3\n" +
+	"\x18GetBlockRelationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"b\n" +
+	"\x19GetBlockRelationsResponse\x12\x1f\n" +
+	"\vblocked_ids\x18\x01 \x03(\tR\n" +
+	"blockedIds\x12$\n" +
+	"\x0eblocked_by_ids\x18\x02 \x03(\tR\fblockedByIds\"N\n" +
+	"\x16GetRelationshipRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"\x8e\x01\n" +
+	"\x17GetRelationshipResponse\x12!\n" +
+	"\fis_following\x18\x01 \x01(\bR\visFollowing\x12$\n" +
+	"\x0eis_followed_by\x18\x02 \x01(\bR\fisFollowedBy\x12*\n" +
+	"\x11target_is_private\x18\x03 \x01(\bR\x0ftargetIsPrivate\"\xa1\x01\n" +
 	"\x18UpdateUserProfileRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
@@ -3527,12 +4199,22 @@ const file_users_users_proto_rawDesc = "" +
 	"\x06gender\x18\x04 \x01(\tR\x06gender\x12.\n" +
 	"\x13profile_picture_url\x18\x05 \x01(\tR\x11profilePictureUrl\"C\n" +
 	"\x19UpdateUserProfileResponse\x12&\n" +
-	"\x04user\x18\x01 \x01(\v2\x12.users.UserProfileR\x04user\"\x80\x01\n" +
+	"\x04user\x18\x01 \x01(\v2\x12.users.UserProfileR\x04user\"\x9a\x03\n" +
+	"\x17NotificationPreferences\x120\n" +
+	"\x05likes\x18\x01 \x01(\x0e2\x1a.users.NotificationChannelR\x05likes\x126\n" +
+	"\bcomments\x18\x02 \x01(\x0e2\x1a.users.NotificationChannelR\bcomments\x124\n" +
+	"\afollows\x18\x03 \x01(\x0e2\x1a.users.NotificationChannelR\afollows\x126\n" +
+	"\bmentions\x18\x04 \x01(\x0e2\x1a.users.NotificationChannelR\bmentions\x12?\n" +
+	"\rstory_replies\x18\x05 \x01(\x0e2\x1a.users.NotificationChannelR\fstoryReplies\x126\n" +
+	"\bmessages\x18\x06 \x01(\x0e2\x1a.users.NotificationChannelR\bmessages\x12.\n" +
+	"\x06digest\x18\a \x01(\x0e2\x16.users.DigestFrequencyR\x06digest\"\xda\x01\n" +
 	"!UpdateNotificationSettingsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\venable_push\x18\x02 \x01(\bR\n" +
 	"enablePush\x12!\n" +
-	"\fenable_email\x18\x03 \x01(\bR\venableEmail\">\n" +
+	"\fenable_email\x18\x03 \x01(\bR\venableEmail\x12@\n" +
+	"\vpreferences\x18\x04 \x01(\v2\x1e.users.NotificationPreferencesR\vpreferences\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\">\n" +
 	"\"UpdateNotificationSettingsResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\"V\n" +
 	"\x1cUpdatePrivacySettingsRequest\x12\x17\n" +
@@ -3544,13 +4226,15 @@ const file_users_users_proto_rawDesc = "" +
 	"\n" +
 	"is_private\x18\x02 \x01(\bR\tisPrivate\"-\n" +
 	"\x12GetSettingsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"x\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xeb\x01\n" +
 	"\x13GetSettingsResponse\x12\x1f\n" +
 	"\venable_push\x18\x01 \x01(\bR\n" +
 	"enablePush\x12!\n" +
 	"\fenable_email\x18\x02 \x01(\bR\venableEmail\x12\x1d\n" +
 	"\n" +
-	"is_private\x18\x03 \x01(\bR\tisPrivate\"V\n" +
+	"is_private\x18\x03 \x01(\bR\tisPrivate\x12Y\n" +
+	"\x18notification_preferences\x18\x04 \x01(\v2\x1e.users.NotificationPreferencesR\x17notificationPreferences\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\"V\n" +
 	"\x15ManageRelationRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
 	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"2\n" +
@@ -3619,9 +4303,19 @@ const file_users_users_proto_rawDesc = "" +
 	"reporterId\x12\x16\n" +
 	"\x06reason\x18\x03 \x01(\tR\x06reason\".\n" +
 	"\x13GetUserEmailRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"D\n" +
 	"\x14GetUserEmailResponse\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email2\x92\x17\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale*M\n" +
+	"\x13NotificationChannel\x12\x12\n" +
+	"\x0eCHANNEL_IN_APP\x10\x00\x12\x11\n" +
+	"\rCHANNEL_EMAIL\x10\x01\x12\x0f\n" +
+	"\vCHANNEL_OFF\x10\x02*F\n" +
+	"\x0fDigestFrequency\x12\x0e\n" +
+	"\n" +
+	"DIGEST_OFF\x10\x00\x12\x10\n" +
+	"\fDIGEST_DAILY\x10\x01\x12\x11\n" +
+	"\rDIGEST_WEEKLY\x10\x022\xce\x19\n" +
 	"\vUserService\x12G\n" +
 	"\fRegisterUser\x12\x1a.users.RegisterUserRequest\x1a\x1b.users.RegisterUserResponse\x128\n" +
 	"\aSendOtp\x12\x15.users.SendOtpRequest\x1a\x16.users.SendOtpResponse\x12F\n" +
@@ -3631,7 +4325,8 @@ const file_users_users_proto_rawDesc = "" +
 	"\x14RequestPasswordReset\x12\".users.RequestPasswordResetRequest\x1a\x16.users.SendOtpResponse\x12R\n" +
 	"\x14PerformPasswordReset\x12\".users.PerformPasswordResetRequest\x1a\x16.users.SendOtpResponse\x12J\n" +
 	"\rValidateToken\x12\x1b.users.ValidateTokenRequest\x1a\x1c.users.ValidateTokenResponse\x12M\n" +
-	"\x0eGetUserProfile\x12\x1c.users.GetUserProfileRequest\x1a\x1d.users.GetUserProfileResponse\x12A\n" +
+	"\x0eGetUserProfile\x12\x1c.users.GetUserProfileRequest\x1a\x1d.users.GetUserProfileResponse\x12P\n" +
+	"\x0fGetUserProfiles\x12\x1d.users.GetUserProfilesRequest\x1a\x1e.users.GetUserProfilesResponse\x12A\n" +
 	"\n" +
 	"FollowUser\x12\x18.users.FollowUserRequest\x1a\x19.users.FollowUserResponse\x12G\n" +
 	"\fUnfollowUser\x12\x1a.users.UnfollowUserRequest\x1a\x1b.users.UnfollowUserResponse\x12S\n" +
@@ -3642,7 +4337,10 @@ const file_users_users_proto_rawDesc = "" +
 	"\x14GetFollowingProfiles\x12\x1e.users.GetFollowingListRequest\x1a#.users.GetFollowingProfilesResponse\x12>\n" +
 	"\tBlockUser\x12\x17.users.BlockUserRequest\x1a\x18.users.BlockUserResponse\x12D\n" +
 	"\vUnblockUser\x12\x19.users.UnblockUserRequest\x1a\x1a.users.UnblockUserResponse\x12M\n" +
-	"\x0eGetBlockedList\x12\x1c.users.GetBlockedListRequest\x1a\x1d.users.GetBlockedListResponse\x12V\n" +
+	"\x0eGetBlockedList\x12\x1c.users.GetBlockedListRequest\x1a\x1d.users.GetBlockedListResponse\x12>\n" +
+	"\tIsBlocked\x12\x17.users.IsBlockedRequest\x1a\x18.users.IsBlockedResponse\x12V\n" +
+	"\x11GetBlockRelations\x12\x1f.users.GetBlockRelationsRequest\x1a .users.GetBlockRelationsResponse\x12P\n" +
+	"\x0fGetRelationship\x12\x1d.users.GetRelationshipRequest\x1a\x1e.users.GetRelationshipResponse\x12V\n" +
 	"\x11UpdateUserProfile\x12\x1f.users.UpdateUserProfileRequest\x1a .users.UpdateUserProfileResponse\x12q\n" +
 	"\x1aUpdateNotificationSettings\x12(.users.UpdateNotificationSettingsRequest\x1a).users.UpdateNotificationSettingsResponse\x12b\n" +
 	"\x15UpdatePrivacySettings\x12#.users.UpdatePrivacySettingsRequest\x1a$.users.UpdatePrivacySettingsResponse\x12D\n" +
@@ -3677,168 +4375,198 @@ func file_users_users_proto_rawDescGZIP() []byte {
 	return file_users_users_proto_rawDescData
 }
 
-var file_users_users_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
+var file_users_users_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_users_users_proto_msgTypes = make([]protoimpl.MessageInfo, 71)
 var file_users_users_proto_goTypes = []any{
-	(*LoginWithGoogleRequest)(nil),             // 0: users.LoginWithGoogleRequest
-	(*TokenResponse)(nil),                      // 1: users.TokenResponse
-	(*LoginUserRequest)(nil),                   // 2: users.LoginUserRequest
-	(*LoginUserResponse)(nil),                  // 3: users.LoginUserResponse
-	(*VerifyLogin2FARequest)(nil),              // 4: users.VerifyLogin2FARequest
-	(*SendOtpRequest)(nil),                     // 5: users.SendOtpRequest
-	(*SendOtpResponse)(nil),                    // 6: users.SendOtpResponse
-	(*RequestPasswordResetRequest)(nil),        // 7: users.RequestPasswordResetRequest
-	(*PerformPasswordResetRequest)(nil),        // 8: users.PerformPasswordResetRequest
-	(*RegisterUserRequest)(nil),                // 9: users.RegisterUserRequest
-	(*RegisterUserResponse)(nil),               // 10: users.RegisterUserResponse
-	(*ValidateTokenRequest)(nil),               // 11: users.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil),              // 12: users.ValidateTokenResponse
-	(*GetUserProfileRequest)(nil),              // 13: users.GetUserProfileRequest
-	(*GetUserProfileResponse)(nil),             // 14: users.GetUserProfileResponse
-	(*FollowUserRequest)(nil),                  // 15: users.FollowUserRequest
-	(*FollowUserResponse)(nil),                 // 16: users.FollowUserResponse
-	(*UnfollowUserRequest)(nil),                // 17: users.UnfollowUserRequest
-	(*UnfollowUserResponse)(nil),               // 18: users.UnfollowUserResponse
-	(*GetFollowingListRequest)(nil),            // 19: users.GetFollowingListRequest
-	(*GetFollowingListResponse)(nil),           // 20: users.GetFollowingListResponse
-	(*UserProfile)(nil),                        // 21: users.UserProfile
-	(*SearchUsersRequest)(nil),                 // 22: users.SearchUsersRequest
-	(*SearchUsersResponse)(nil),                // 23: users.SearchUsersResponse
-	(*GetUserByUsernameRequest)(nil),           // 24: users.GetUserByUsernameRequest
-	(*GetSuggestedUsersRequest)(nil),           // 25: users.GetSuggestedUsersRequest
-	(*GetSuggestedUsersResponse)(nil),          // 26: users.GetSuggestedUsersResponse
-	(*GetFollowingProfilesResponse)(nil),       // 27: users.GetFollowingProfilesResponse
-	(*BlockUserRequest)(nil),                   // 28: users.BlockUserRequest
-	(*BlockUserResponse)(nil),                  // 29: users.BlockUserResponse
-	(*UnblockUserRequest)(nil),                 // 30: users.UnblockUserRequest
-	(*UnblockUserResponse)(nil),                // 31: users.UnblockUserResponse
-	(*GetBlockedListRequest)(nil),              // 32: users.GetBlockedListRequest
-	(*GetBlockedListResponse)(nil),             // 33: users.GetBlockedListResponse
-	(*UpdateUserProfileRequest)(nil),           // 34: users.UpdateUserProfileRequest
-	(*UpdateUserProfileResponse)(nil),          // 35: users.UpdateUserProfileResponse
-	(*UpdateNotificationSettingsRequest)(nil),  // 36: users.UpdateNotificationSettingsRequest
-	(*UpdateNotificationSettingsResponse)(nil), // 37: users.UpdateNotificationSettingsResponse
-	(*UpdatePrivacySettingsRequest)(nil),       // 38: users.UpdatePrivacySettingsRequest
-	(*UpdatePrivacySettingsResponse)(nil),      // 39: users.UpdatePrivacySettingsResponse
-	(*GetSettingsRequest)(nil),                 // 40: users.GetSettingsRequest
-	(*GetSettingsResponse)(nil),                // 41: users.GetSettingsResponse
-	(*ManageRelationRequest)(nil),              // 42: users.ManageRelationRequest
-	(*ManageRelationResponse)(nil),             // 43: users.ManageRelationResponse
-	(*GetListRequest)(nil),                     // 44: users.GetListRequest
-	(*GetListResponse)(nil),                    // 45: users.GetListResponse
-	(*RequestVerificationRequest)(nil),         // 46: users.RequestVerificationRequest
-	(*RequestVerificationResponse)(nil),        // 47: users.RequestVerificationResponse
-	(*Empty)(nil),                              // 48: users.Empty
-	(*UserListResponse)(nil),                   // 49: users.UserListResponse
-	(*ToggleUserBanRequest)(nil),               // 50: users.ToggleUserBanRequest
-	(*EmailListResponse)(nil),                  // 51: users.EmailListResponse
-	(*VerificationRequestItem)(nil),            // 52: users.VerificationRequestItem
-	(*VerificationListResponse)(nil),           // 53: users.VerificationListResponse
-	(*ReviewVerificationRequest)(nil),          // 54: users.ReviewVerificationRequest
-	(*Response)(nil),                           // 55: users.Response
-	(*UserReportItem)(nil),                     // 56: users.UserReportItem
-	(*UserReportListResponse)(nil),             // 57: users.UserReportListResponse
-	(*ReviewReportRequest)(nil),                // 58: users.ReviewReportRequest
-	(*ReportUserRequest)(nil),                  // 59: users.ReportUserRequest
-	(*GetUserEmailRequest)(nil),                // 60: users.GetUserEmailRequest
-	(*GetUserEmailResponse)(nil),               // 61: users.GetUserEmailResponse
-	(*timestamppb.Timestamp)(nil),              // 62: google.protobuf.Timestamp
+	(NotificationChannel)(0),                   // 0: users.NotificationChannel
+	(DigestFrequency)(0),                       // 1: users.DigestFrequency
+	(*LoginWithGoogleRequest)(nil),             // 2: users.LoginWithGoogleRequest
+	(*TokenResponse)(nil),                      // 3: users.TokenResponse
+	(*LoginUserRequest)(nil),                   // 4: users.LoginUserRequest
+	(*LoginUserResponse)(nil),                  // 5: users.LoginUserResponse
+	(*VerifyLogin2FARequest)(nil),              // 6: users.VerifyLogin2FARequest
+	(*SendOtpRequest)(nil),                     // 7: users.SendOtpRequest
+	(*SendOtpResponse)(nil),                    // 8: users.SendOtpResponse
+	(*RequestPasswordResetRequest)(nil),        // 9: users.RequestPasswordResetRequest
+	(*PerformPasswordResetRequest)(nil),        // 10: users.PerformPasswordResetRequest
+	(*RegisterUserRequest)(nil),                // 11: users.RegisterUserRequest
+	(*RegisterUserResponse)(nil),               // 12: users.RegisterUserResponse
+	(*ValidateTokenRequest)(nil),               // 13: users.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),              // 14: users.ValidateTokenResponse
+	(*GetUserProfileRequest)(nil),              // 15: users.GetUserProfileRequest
+	(*GetUserProfileResponse)(nil),             // 16: users.GetUserProfileResponse
+	(*GetUserProfilesRequest)(nil),             // 17: users.GetUserProfilesRequest
+	(*GetUserProfilesResponse)(nil),            // 18: users.GetUserProfilesResponse
+	(*FollowUserRequest)(nil),                  // 19: users.FollowUserRequest
+	(*FollowUserResponse)(nil),                 // 20: users.FollowUserResponse
+	(*UnfollowUserRequest)(nil),                // 21: users.UnfollowUserRequest
+	(*UnfollowUserResponse)(nil),               // 22: users.UnfollowUserResponse
+	(*GetFollowingListRequest)(nil),            // 23: users.GetFollowingListRequest
+	(*GetFollowingListResponse)(nil),           // 24: users.GetFollowingListResponse
+	(*UserProfile)(nil),                        // 25: users.UserProfile
+	(*SearchUsersRequest)(nil),                 // 26: users.SearchUsersRequest
+	(*SearchUsersResponse)(nil),                // 27: users.SearchUsersResponse
+	(*GetUserByUsernameRequest)(nil),           // 28: users.GetUserByUsernameRequest
+	(*GetSuggestedUsersRequest)(nil),           // 29: users.GetSuggestedUsersRequest
+	(*GetSuggestedUsersResponse)(nil),          // 30: users.GetSuggestedUsersResponse
+	(*GetFollowingProfilesResponse)(nil),       // 31: users.GetFollowingProfilesResponse
+	(*BlockUserRequest)(nil),                   // 32: users.BlockUserRequest
+	(*BlockUserResponse)(nil),                  // 33: users.BlockUserResponse
+	(*UnblockUserRequest)(nil),                 // 34: users.UnblockUserRequest
+	(*UnblockUserResponse)(nil),                // 35: users.UnblockUserResponse
+	(*GetBlockedListRequest)(nil),              // 36: users.GetBlockedListRequest
+	(*GetBlockedListResponse)(nil),             // 37: users.GetBlockedListResponse
+	(*IsBlockedRequest)(nil),                   // 38: users.IsBlockedRequest
+	(*IsBlockedResponse)(nil),                  // 39: users.IsBlockedResponse
+	(*GetBlockRelationsRequest)(nil),           // 40: users.GetBlockRelationsRequest
+	(*GetBlockRelationsResponse)(nil),          // 41: users.GetBlockRelationsResponse
+	(*GetRelationshipRequest)(nil),             // 42: users.GetRelationshipRequest
+	(*GetRelationshipResponse)(nil),            // 43: users.GetRelationshipResponse
+	(*UpdateUserProfileRequest)(nil),           // 44: users.UpdateUserProfileRequest
+	(*UpdateUserProfileResponse)(nil),          // 45: users.UpdateUserProfileResponse
+	(*NotificationPreferences)(nil),            // 46: users.NotificationPreferences
+	(*UpdateNotificationSettingsRequest)(nil),  // 47: users.UpdateNotificationSettingsRequest
+	(*UpdateNotificationSettingsResponse)(nil), // 48: users.UpdateNotificationSettingsResponse
+	(*UpdatePrivacySettingsRequest)(nil),       // 49: users.UpdatePrivacySettingsRequest
+	(*UpdatePrivacySettingsResponse)(nil),      // 50: users.UpdatePrivacySettingsResponse
+	(*GetSettingsRequest)(nil),                 // 51: users.GetSettingsRequest
+	(*GetSettingsResponse)(nil),                // 52: users.GetSettingsResponse
+	(*ManageRelationRequest)(nil),              // 53: users.ManageRelationRequest
+	(*ManageRelationResponse)(nil),             // 54: users.ManageRelationResponse
+	(*GetListRequest)(nil),                     // 55: users.GetListRequest
+	(*GetListResponse)(nil),                    // 56: users.GetListResponse
+	(*RequestVerificationRequest)(nil),         // 57: users.RequestVerificationRequest
+	(*RequestVerificationResponse)(nil),        // 58: users.RequestVerificationResponse
+	(*Empty)(nil),                              // 59: users.Empty
+	(*UserListResponse)(nil),                   // 60: users.UserListResponse
+	(*ToggleUserBanRequest)(nil),               // 61: users.ToggleUserBanRequest
+	(*EmailListResponse)(nil),                  // 62: users.EmailListResponse
+	(*VerificationRequestItem)(nil),            // 63: users.VerificationRequestItem
+	(*VerificationListResponse)(nil),           // 64: users.VerificationListResponse
+	(*ReviewVerificationRequest)(nil),          // 65: users.ReviewVerificationRequest
+	(*Response)(nil),                           // 66: users.Response
+	(*UserReportItem)(nil),                     // 67: users.UserReportItem
+	(*UserReportListResponse)(nil),             // 68: users.UserReportListResponse
+	(*ReviewReportRequest)(nil),                // 69: users.ReviewReportRequest
+	(*ReportUserRequest)(nil),                  // 70: users.ReportUserRequest
+	(*GetUserEmailRequest)(nil),                // 71: users.GetUserEmailRequest
+	(*GetUserEmailResponse)(nil),               // 72: users.GetUserEmailResponse
+	(*timestamppb.Timestamp)(nil),              // 73: google.protobuf.Timestamp
 }
 var file_users_users_proto_depIdxs = []int32{
-	1,  // 0: users.LoginUserResponse.tokens:type_name -> users.TokenResponse
-	62, // 1: users.RegisterUserRequest.date_of_birth:type_name -> google.protobuf.Timestamp
-	62, // 2: users.RegisterUserResponse.date_of_birth:type_name -> google.protobuf.Timestamp
-	21, // 3: users.SearchUsersResponse.users:type_name -> users.UserProfile
-	21, // 4: users.GetSuggestedUsersResponse.users:type_name -> users.UserProfile
-	21, // 5: users.GetFollowingProfilesResponse.users:type_name -> users.UserProfile
-	21, // 6: users.GetBlockedListResponse.users:type_name -> users.UserProfile
-	21, // 7: users.UpdateUserProfileResponse.user:type_name -> users.UserProfile
-	21, // 8: users.GetListResponse.users:type_name -> users.UserProfile
-	21, // 9: users.UserListResponse.users:type_name -> users.UserProfile
-	52, // 10: users.VerificationListResponse.requests:type_name -> users.VerificationRequestItem
-	56, // 11: users.UserReportListResponse.reports:type_name -> users.UserReportItem
-	9,  // 12: users.UserService.RegisterUser:input_type -> users.RegisterUserRequest
-	5,  // 13: users.UserService.SendOtp:input_type -> users.SendOtpRequest
-	0,  // 14: users.UserService.LoginWithGoogle:input_type -> users.LoginWithGoogleRequest
-	2,  // 15: users.UserService.LoginUser:input_type -> users.LoginUserRequest
-	4,  // 16: users.UserService.VerifyLogin2FA:input_type -> users.VerifyLogin2FARequest
-	7,  // 17: users.UserService.RequestPasswordReset:input_type -> users.RequestPasswordResetRequest
-	8,  // 18: users.UserService.PerformPasswordReset:input_type -> users.PerformPasswordResetRequest
-	11, // 19: users.UserService.ValidateToken:input_type -> users.ValidateTokenRequest
-	13, // 20: users.UserService.GetUserProfile:input_type -> users.GetUserProfileRequest
-	15, // 21: users.UserService.FollowUser:input_type -> users.FollowUserRequest
-	17, // 22: users.UserService.UnfollowUser:input_type -> users.UnfollowUserRequest
-	19, // 23: users.UserService.GetFollowingList:input_type -> users.GetFollowingListRequest
-	22, // 24: users.UserService.SearchUsers:input_type -> users.SearchUsersRequest
-	24, // 25: users.UserService.GetUserByUsername:input_type -> users.GetUserByUsernameRequest
-	25, // 26: users.UserService.GetSuggestedUsers:input_type -> users.GetSuggestedUsersRequest
-	19, // 27: users.UserService.GetFollowingProfiles:input_type -> users.GetFollowingListRequest
-	28, // 28: users.UserService.BlockUser:input_type -> users.BlockUserRequest
-	30, // 29: users.UserService.UnblockUser:input_type -> users.UnblockUserRequest
-	32, // 30: users.UserService.GetBlockedList:input_type -> users.GetBlockedListRequest
-	34, // 31: users.UserService.UpdateUserProfile:input_type -> users.UpdateUserProfileRequest
-	36, // 32: users.UserService.UpdateNotificationSettings:input_type -> users.UpdateNotificationSettingsRequest
-	38, // 33: users.UserService.UpdatePrivacySettings:input_type -> users.UpdatePrivacySettingsRequest
-	40, // 34: users.UserService.GetSettings:input_type -> users.GetSettingsRequest
-	42, // 35: users.UserService.AddCloseFriend:input_type -> users.ManageRelationRequest
-	42, // 36: users.UserService.RemoveCloseFriend:input_type -> users.ManageRelationRequest
-	44, // 37: users.UserService.GetCloseFriends:input_type -> users.GetListRequest
-	42, // 38: users.UserService.HideStoryFromUser:input_type -> users.ManageRelationRequest
-	42, // 39: users.UserService.UnhideStoryFromUser:input_type -> users.ManageRelationRequest
-	44, // 40: users.UserService.GetHiddenStoryUsers:input_type -> users.GetListRequest
-	46, // 41: users.UserService.RequestVerification:input_type -> users.RequestVerificationRequest
-	48, // 42: users.UserService.GetAllUsers:input_type -> users.Empty
-	50, // 43: users.UserService.ToggleUserBan:input_type -> users.ToggleUserBanRequest
-	48, // 44: users.UserService.GetSubscribedEmails:input_type -> users.Empty
-	48, // 45: users.UserService.GetVerificationRequests:input_type -> users.Empty
-	54, // 46: users.UserService.ReviewVerification:input_type -> users.ReviewVerificationRequest
-	48, // 47: users.UserService.GetUserReports:input_type -> users.Empty
-	58, // 48: users.UserService.ReviewUserReport:input_type -> users.ReviewReportRequest
-	59, // 49: users.UserService.ReportUser:input_type -> users.ReportUserRequest
-	60, // 50: users.UserService.GetUserEmail:input_type -> users.GetUserEmailRequest
-	10, // 51: users.UserService.RegisterUser:output_type -> users.RegisterUserResponse
-	6,  // 52: users.UserService.SendOtp:output_type -> users.SendOtpResponse
-	1,  // 53: users.UserService.LoginWithGoogle:output_type -> users.TokenResponse
-	3,  // 54: users.UserService.LoginUser:output_type -> users.LoginUserResponse
-	1,  // 55: users.UserService.VerifyLogin2FA:output_type -> users.TokenResponse
-	6,  // 56: users.UserService.RequestPasswordReset:output_type -> users.SendOtpResponse
-	6,  // 57: users.UserService.PerformPasswordReset:output_type -> users.SendOtpResponse
-	12, // 58: users.UserService.ValidateToken:output_type -> users.ValidateTokenResponse
-	14, // 59: users.UserService.GetUserProfile:output_type -> users.GetUserProfileResponse
-	16, // 60: users.UserService.FollowUser:output_type -> users.FollowUserResponse
-	18, // 61: users.UserService.UnfollowUser:output_type -> users.UnfollowUserResponse
-	20, // 62: users.UserService.GetFollowingList:output_type -> users.GetFollowingListResponse
-	23, // 63: users.UserService.SearchUsers:output_type -> users.SearchUsersResponse
-	14, // 64: users.UserService.GetUserByUsername:output_type -> users.GetUserProfileResponse
-	26, // 65: users.UserService.GetSuggestedUsers:output_type -> users.GetSuggestedUsersResponse
-	27, // 66: users.UserService.GetFollowingProfiles:output_type -> users.GetFollowingProfilesResponse
-	29, // 67: users.UserService.BlockUser:output_type -> users.BlockUserResponse
-	31, // 68: users.UserService.UnblockUser:output_type -> users.UnblockUserResponse
-	33, // 69: users.UserService.GetBlockedList:output_type -> users.GetBlockedListResponse
-	35, // 70: users.UserService.UpdateUserProfile:output_type -> users.UpdateUserProfileResponse
-	37, // 71: users.UserService.UpdateNotificationSettings:output_type -> users.UpdateNotificationSettingsResponse
-	39, // 72: users.UserService.UpdatePrivacySettings:output_type -> users.UpdatePrivacySettingsResponse
-	41, // 73: users.UserService.GetSettings:output_type -> users.GetSettingsResponse
-	43, // 74: users.UserService.AddCloseFriend:output_type -> users.ManageRelationResponse
-	43, // 75: users.UserService.RemoveCloseFriend:output_type -> users.ManageRelationResponse
-	45, // 76: users.UserService.GetCloseFriends:output_type -> users.GetListResponse
-	43, // 77: users.UserService.HideStoryFromUser:output_type -> users.ManageRelationResponse
-	43, // 78: users.UserService.UnhideStoryFromUser:output_type -> users.ManageRelationResponse
-	45, // 79: users.UserService.GetHiddenStoryUsers:output_type -> users.GetListResponse
-	47, // 80: users.UserService.RequestVerification:output_type -> users.RequestVerificationResponse
-	49, // 81: users.UserService.GetAllUsers:output_type -> users.UserListResponse
-	55, // 82: users.UserService.ToggleUserBan:output_type -> users.Response
-	51, // 83: users.UserService.GetSubscribedEmails:output_type -> users.EmailListResponse
-	53, // 84: users.UserService.GetVerificationRequests:output_type -> users.VerificationListResponse
-	55, // 85: users.UserService.ReviewVerification:output_type -> users.Response
-	57, // 86: users.UserService.GetUserReports:output_type -> users.UserReportListResponse
-	55, // 87: users.UserService.ReviewUserReport:output_type -> users.Response
-	55, // 88: users.UserService.ReportUser:output_type -> users.Response
-	61, // 89: users.UserService.GetUserEmail:output_type -> users.GetUserEmailResponse
-	51, // [51:90] is the sub-list for method output_type
-	12, // [12:51] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	3,  // 0: users.LoginUserResponse.tokens:type_name -> users.TokenResponse
+	73, // 1: users.RegisterUserRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	73, // 2: users.RegisterUserResponse.date_of_birth:type_name -> google.protobuf.Timestamp
+	25, // 3: users.GetUserProfilesResponse.users:type_name -> users.UserProfile
+	25, // 4: users.SearchUsersResponse.users:type_name -> users.UserProfile
+	25, // 5: users.GetSuggestedUsersResponse.users:type_name -> users.UserProfile
+	25, // 6: users.GetFollowingProfilesResponse.users:type_name -> users.UserProfile
+	25, // 7: users.GetBlockedListResponse.users:type_name -> users.UserProfile
+	25, // 8: users.UpdateUserProfileResponse.user:type_name -> users.UserProfile
+	0,  // 9: users.NotificationPreferences.likes:type_name -> users.NotificationChannel
+	0,  // 10: users.NotificationPreferences.comments:type_name -> users.NotificationChannel
+	0,  // 11: users.NotificationPreferences.follows:type_name -> users.NotificationChannel
+	0,  // 12: users.NotificationPreferences.mentions:type_name -> users.NotificationChannel
+	0,  // 13: users.NotificationPreferences.story_replies:type_name -> users.NotificationChannel
+	0,  // 14: users.NotificationPreferences.messages:type_name -> users.NotificationChannel
+	1,  // 15: users.NotificationPreferences.digest:type_name -> users.DigestFrequency
+	46, // 16: users.UpdateNotificationSettingsRequest.preferences:type_name -> users.NotificationPreferences
+	46, // 17: users.GetSettingsResponse.notification_preferences:type_name -> users.NotificationPreferences
+	25, // 18: users.GetListResponse.users:type_name -> users.UserProfile
+	25, // 19: users.UserListResponse.users:type_name -> users.UserProfile
+	63, // 20: users.VerificationListResponse.requests:type_name -> users.VerificationRequestItem
+	67, // 21: users.UserReportListResponse.reports:type_name -> users.UserReportItem
+	11, // 22: users.UserService.RegisterUser:input_type -> users.RegisterUserRequest
+	7,  // 23: users.UserService.SendOtp:input_type -> users.SendOtpRequest
+	2,  // 24: users.UserService.LoginWithGoogle:input_type -> users.LoginWithGoogleRequest
+	4,  // 25: users.UserService.LoginUser:input_type -> users.LoginUserRequest
+	6,  // 26: users.UserService.VerifyLogin2FA:input_type -> users.VerifyLogin2FARequest
+	9,  // 27: users.UserService.RequestPasswordReset:input_type -> users.RequestPasswordResetRequest
+	10, // 28: users.UserService.PerformPasswordReset:input_type -> users.PerformPasswordResetRequest
+	13, // 29: users.UserService.ValidateToken:input_type -> users.ValidateTokenRequest
+	15, // 30: users.UserService.GetUserProfile:input_type -> users.GetUserProfileRequest
+	17, // 31: users.UserService.GetUserProfiles:input_type -> users.GetUserProfilesRequest
+	19, // 32: users.UserService.FollowUser:input_type -> users.FollowUserRequest
+	21, // 33: users.UserService.UnfollowUser:input_type -> users.UnfollowUserRequest
+	23, // 34: users.UserService.GetFollowingList:input_type -> users.GetFollowingListRequest
+	26, // 35: users.UserService.SearchUsers:input_type -> users.SearchUsersRequest
+	28, // 36: users.UserService.GetUserByUsername:input_type -> users.GetUserByUsernameRequest
+	29, // 37: users.UserService.GetSuggestedUsers:input_type -> users.GetSuggestedUsersRequest
+	23, // 38: users.UserService.GetFollowingProfiles:input_type -> users.GetFollowingListRequest
+	32, // 39: users.UserService.BlockUser:input_type -> users.BlockUserRequest
+	34, // 40: users.UserService.UnblockUser:input_type -> users.UnblockUserRequest
+	36, // 41: users.UserService.GetBlockedList:input_type -> users.GetBlockedListRequest
+	38, // 42: users.UserService.IsBlocked:input_type -> users.IsBlockedRequest
+	40, // 43: users.UserService.GetBlockRelations:input_type -> users.GetBlockRelationsRequest
+	42, // 44: users.UserService.GetRelationship:input_type -> users.GetRelationshipRequest
+	44, // 45: users.UserService.UpdateUserProfile:input_type -> users.UpdateUserProfileRequest
+	47, // 46: users.UserService.UpdateNotificationSettings:input_type -> users.UpdateNotificationSettingsRequest
+	49, // 47: users.UserService.UpdatePrivacySettings:input_type -> users.UpdatePrivacySettingsRequest
+	51, // 48: users.UserService.GetSettings:input_type -> users.GetSettingsRequest
+	53, // 49: users.UserService.AddCloseFriend:input_type -> users.ManageRelationRequest
+	53, // 50: users.UserService.RemoveCloseFriend:input_type -> users.ManageRelationRequest
+	55, // 51: users.UserService.GetCloseFriends:input_type -> users.GetListRequest
+	53, // 52: users.UserService.HideStoryFromUser:input_type -> users.ManageRelationRequest
+	53, // 53: users.UserService.UnhideStoryFromUser:input_type -> users.ManageRelationRequest
+	55, // 54: users.UserService.GetHiddenStoryUsers:input_type -> users.GetListRequest
+	57, // 55: users.UserService.RequestVerification:input_type -> users.RequestVerificationRequest
+	59, // 56: users.UserService.GetAllUsers:input_type -> users.Empty
+	61, // 57: users.UserService.ToggleUserBan:input_type -> users.ToggleUserBanRequest
+	59, // 58: users.UserService.GetSubscribedEmails:input_type -> users.Empty
+	59, // 59: users.UserService.GetVerificationRequests:input_type -> users.Empty
+	65, // 60: users.UserService.ReviewVerification:input_type -> users.ReviewVerificationRequest
+	59, // 61: users.UserService.GetUserReports:input_type -> users.Empty
+	69, // 62: users.UserService.ReviewUserReport:input_type -> users.ReviewReportRequest
+	70, // 63: users.UserService.ReportUser:input_type -> users.ReportUserRequest
+	71, // 64: users.UserService.GetUserEmail:input_type -> users.GetUserEmailRequest
+	12, // 65: users.UserService.RegisterUser:output_type -> users.RegisterUserResponse
+	8,  // 66: users.UserService.SendOtp:output_type -> users.SendOtpResponse
+	3,  // 67: users.UserService.LoginWithGoogle:output_type -> users.TokenResponse
+	5,  // 68: users.UserService.LoginUser:output_type -> users.LoginUserResponse
+	3,  // 69: users.UserService.VerifyLogin2FA:output_type -> users.TokenResponse
+	8,  // 70: users.UserService.RequestPasswordReset:output_type -> users.SendOtpResponse
+	8,  // 71: users.UserService.PerformPasswordReset:output_type -> users.SendOtpResponse
+	14, // 72: users.UserService.ValidateToken:output_type -> users.ValidateTokenResponse
+	16, // 73: users.UserService.GetUserProfile:output_type -> users.GetUserProfileResponse
+	18, // 74: users.UserService.GetUserProfiles:output_type -> users.GetUserProfilesResponse
+	20, // 75: users.UserService.FollowUser:output_type -> users.FollowUserResponse
+	22, // 76: users.UserService.UnfollowUser:output_type -> users.UnfollowUserResponse
+	24, // 77: users.UserService.GetFollowingList:output_type -> users.GetFollowingListResponse
+	27, // 78: users.UserService.SearchUsers:output_type -> users.SearchUsersResponse
+	16, // 79: users.UserService.GetUserByUsername:output_type -> users.GetUserProfileResponse
+	30, // 80: users.UserService.GetSuggestedUsers:output_type -> users.GetSuggestedUsersResponse
+	31, // 81: users.UserService.GetFollowingProfiles:output_type -> users.GetFollowingProfilesResponse
+	33, // 82: users.UserService.BlockUser:output_type -> users.BlockUserResponse
+	35, // 83: users.UserService.UnblockUser:output_type -> users.UnblockUserResponse
+	37, // 84: users.UserService.GetBlockedList:output_type -> users.GetBlockedListResponse
+	39, // 85: users.UserService.IsBlocked:output_type -> users.IsBlockedResponse
+	41, // 86: users.UserService.GetBlockRelations:output_type -> users.GetBlockRelationsResponse
+	43, // 87: users.UserService.GetRelationship:output_type -> users.GetRelationshipResponse
+	45, // 88: users.UserService.UpdateUserProfile:output_type -> users.UpdateUserProfileResponse
+	48, // 89: users.UserService.UpdateNotificationSettings:output_type -> users.UpdateNotificationSettingsResponse
+	50, // 90: users.UserService.UpdatePrivacySettings:output_type -> users.UpdatePrivacySettingsResponse
+	52, // 91: users.UserService.GetSettings:output_type -> users.GetSettingsResponse
+	54, // 92: users.UserService.AddCloseFriend:output_type -> users.ManageRelationResponse
+	54, // 93: users.UserService.RemoveCloseFriend:output_type -> users.ManageRelationResponse
+	56, // 94: users.UserService.GetCloseFriends:output_type -> users.GetListResponse
+	54, // 95: users.UserService.HideStoryFromUser:output_type -> users.ManageRelationResponse
+	54, // 96: users.UserService.UnhideStoryFromUser:output_type -> users.ManageRelationResponse
+	56, // 97: users.UserService.GetHiddenStoryUsers:output_type -> users.GetListResponse
+	58, // 98: users.UserService.RequestVerification:output_type -> users.RequestVerificationResponse
+	60, // 99: users.UserService.GetAllUsers:output_type -> users.UserListResponse
+	66, // 100: users.UserService.ToggleUserBan:output_type -> users.Response
+	62, // 101: users.UserService.GetSubscribedEmails:output_type -> users.EmailListResponse
+	64, // 102: users.UserService.GetVerificationRequests:output_type -> users.VerificationListResponse
+	66, // 103: users.UserService.ReviewVerification:output_type -> users.Response
+	68, // 104: users.UserService.GetUserReports:output_type -> users.UserReportListResponse
+	66, // 105: users.UserService.ReviewUserReport:output_type -> users.Response
+	66, // 106: users.UserService.ReportUser:output_type -> users.Response
+	72, // 107: users.UserService.GetUserEmail:output_type -> users.GetUserEmailResponse
+	65, // [65:108] is the sub-list for method output_type
+	22, // [22:65] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_users_users_proto_init() }
@@ -3851,13 +4579,14 @@ func file_users_users_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_users_proto_rawDesc), len(file_users_users_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   62,
+			NumEnums:      2,
+			NumMessages:   71,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_users_users_proto_goTypes,
 		DependencyIndexes: file_users_users_proto_depIdxs,
+		EnumInfos:         file_users_users_proto_enumTypes,
 		MessageInfos:      file_users_users_proto_msgTypes,
 	}.Build()
 	File_users_users_proto = out.File
@@ -1,21 +1,31 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"os"
 	"strings"
 
+	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/chat"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/clients"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/export"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/handlers"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/media"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/ws"
 	chatHttp "github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/delivery/http"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -40,10 +50,25 @@ func main() {
 	}
 
 
-	err = db.AutoMigrate(&domain.Conversation{}, &domain.Participant{}, &domain.Message{})
+	err = db.AutoMigrate(
+		&domain.Conversation{},
+		&domain.Participant{},
+		&domain.Message{},
+		&domain.MessageReaction{},
+		&domain.MessageEdit{},
+		&domain.MessageDelivery{},
+		&domain.Attachment{},
+		&domain.Call{},
+		&domain.ExportJob{},
+		&domain.UserEvent{},
+		&domain.UserEventSequence{},
+	)
 	if err != nil {
 		log.Printf("Warning: AutoMigration failed: %v", err)
 	}
+	if err := repositories.MigrateSearch(db); err != nil {
+		log.Printf("Warning: search index migration failed: %v", err)
+	}
 
 	redisAddr := os.Getenv("REDIS_ADDR")
 	if redisAddr == "" {
@@ -56,10 +81,99 @@ func main() {
 
 	chatRepo := repositories.NewChatRepository(db)
 
-	hub := ws.NewHub(rdb)
+	var userClient *clients.UserServiceClient
+	if addr := os.Getenv("USER_SERVICE_URL"); addr != "" {
+		userClient, err = clients.NewUserServiceClient(addr)
+		if err != nil {
+			log.Printf("Warning: block and privacy checks disabled: %v", err)
+		}
+	} else {
+		log.Println("Warning: USER_SERVICE_URL not set, block and privacy checks disabled")
+	}
+
+	minioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	if minioEndpoint == "" {
+		minioEndpoint = "localhost:9000"
+	}
+
+	var mediaStore *media.Store
+	minioClient, err := clients.NewMinioClient(
+		minioEndpoint,
+		os.Getenv("MINIO_PUBLIC_ENDPOINT"),
+		os.Getenv("MINIO_ACCESS_KEY_ID"),
+		os.Getenv("MINIO_SECRET_ACCESS_KEY"),
+		os.Getenv("MINIO_BUCKET_NAME"),
+		os.Getenv("MINIO_USE_SSL") == "true",
+	)
+	if err != nil {
+		log.Printf("Warning: attachments disabled: %v", err)
+	} else {
+		mediaStore = media.NewStore(minioClient, chatRepo)
+	}
+
+	var publisher *events.Publisher
+	if url := os.Getenv("RABBITMQ_URL"); url != "" {
+		publisher, err = events.NewPublisher(url)
+		if err != nil {
+			log.Printf("Warning: offline notifications disabled: %v", err)
+		} else {
+			defer publisher.Close()
+		}
+	} else {
+		log.Println("Warning: RABBITMQ_URL not set, offline notifications disabled")
+	}
+
+	var tokenProvider calls.TokenProvider
+	if agora, err := calls.NewAgoraProvider(os.Getenv("AGORA_APP_ID"), os.Getenv("AGORA_APP_CERTIFICATE")); err != nil {
+		log.Printf("Warning: call tokens disabled: %v", err)
+	} else {
+		tokenProvider = agora
+	}
+	callService := calls.NewService(chatRepo, tokenProvider)
+
+	hub := ws.NewHub(rdb, chatRepo, userClient, mediaStore, publisher, callService)
 	go hub.Run() 
+	go hub.RunExpirySweeper(context.Background())
+
+	exportBucket := os.Getenv("MINIO_EXPORT_BUCKET_NAME")
+	if exportBucket == "" {
+		exportBucket = "chat-exports"
+	}
+	exportClient, err := clients.NewMinioClient(
+		minioEndpoint,
+		os.Getenv("MINIO_PUBLIC_ENDPOINT"),
+		os.Getenv("MINIO_ACCESS_KEY_ID"),
+		os.Getenv("MINIO_SECRET_ACCESS_KEY"),
+		exportBucket,
+		os.Getenv("MINIO_USE_SSL") == "true",
+	)
+	if err != nil {
+		log.Printf("Warning: conversation exports disabled: %v", err)
+	}
+	exportService := export.NewService(chatRepo, mediaStore, exportClient, userClient, publisher)
+	go exportService.Run(context.Background())
 
-	chatHandler := chatHttp.NewChatHandler(chatRepo, hub)
+	chatHandler := chatHttp.NewChatHandler(chatRepo, hub, mediaStore, exportService)
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50053"
+	}
+
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterChatServiceServer(grpcServer, handlers.NewChatGRPCServer(chatRepo, hub, mediaStore))
+
+	go func() {
+		log.Printf("Chat gRPC server listening on %v", lis.Addr())
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to serve gRPC: %v", err)
+		}
+	}()
 
 	r := gin.Default()
 
@@ -0,0 +1,45 @@
+package calls
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AgoraIO/Tools/DynamicKey/AgoraDynamicKey/go/src/rtctokenbuilder2"
+)
+
+// TokenProvider issues the credentials clients use to join a call's media
+// channel.
+type TokenProvider interface {
+	AppID() string
+	RTCToken(channelName string, uid uint32, ttl time.Duration) (string, error)
+}
+
+// AgoraProvider signs Agora RTC tokens with the project's app certificate.
+type AgoraProvider struct {
+	appID       string
+	certificate string
+}
+
+func NewAgoraProvider(appID, certificate string) (*AgoraProvider, error) {
+	if appID == "" || certificate == "" {
+		return nil, errors.New("AGORA_APP_ID or AGORA_APP_CERTIFICATE not set")
+	}
+	return &AgoraProvider{appID: appID, certificate: certificate}, nil
+}
+
+func (p *AgoraProvider) AppID() string {
+	return p.appID
+}
+
+func (p *AgoraProvider) RTCToken(channelName string, uid uint32, ttl time.Duration) (string, error) {
+	seconds := uint32(ttl / time.Second)
+	return rtctokenbuilder2.BuildTokenWithUid(
+		p.appID,
+		p.certificate,
+		channelName,
+		uid,
+		rtctokenbuilder2.RolePublisher,
+		seconds,
+		seconds,
+	)
+}
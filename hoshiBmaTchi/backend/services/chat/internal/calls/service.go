@@ -0,0 +1,234 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"hash/crc32"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	// RingTimeout is how long a call rings before it counts as missed.
+	RingTimeout = 45 * time.Second
+
+	// TokenTTL keeps leaked tokens short-lived. Clients fetch a fresh token
+	// when the SDK warns that the current one is about to expire.
+	TokenTTL = 15 * time.Minute
+
+	// MaxCallDuration ends calls whose clients vanished without hanging up,
+	// so they do not block new calls in the conversation forever.
+	MaxCallDuration = 6 * time.Hour
+)
+
+var (
+	ErrNotConfigured   = errors.New("calls are not configured")
+	ErrInvalidCallType = errors.New("call type must be audio or video")
+	ErrCallerAction    = errors.New("the caller cannot answer their own call")
+)
+
+// Store is the persistence the call service needs. ChatRepository
+// implements it.
+type Store interface {
+	GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error)
+	GetParticipant(ctx context.Context, conversationID, userID string) (*domain.Participant, error)
+	CreateCall(ctx context.Context, call *domain.Call) error
+	GetCall(ctx context.Context, callID string) (*domain.Call, error)
+	ActiveCall(ctx context.Context, conversationID string) (*domain.Call, error)
+	TransitionCall(ctx context.Context, callID string, from []string, to string, at time.Time) (*domain.Call, error)
+}
+
+// Credentials let one user join a conversation's media channel.
+type Credentials struct {
+	AppID       string    `json:"app_id"`
+	ChannelName string    `json:"channel_name"`
+	Token       string    `json:"token"`
+	UID         uint32    `json:"uid"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Service runs the call state machine and hands out media tokens to
+// conversation members.
+type Service struct {
+	store  Store
+	tokens TokenProvider
+	now    func() time.Time
+}
+
+// NewService builds a call service. tokens may be nil, in which case calls
+// can still be signalled but no media tokens are issued.
+func NewService(store Store, tokens TokenProvider) *Service {
+	return &Service{store: store, tokens: tokens, now: time.Now}
+}
+
+// UID derives a stable media UID from a user ID, so every member of a call
+// has their own UID and a token cannot be reused by someone else.
+func UID(userID string) uint32 {
+	return crc32.ChecksumIEEE([]byte(userID))
+}
+
+// Token issues short-lived publisher credentials for the conversation's
+// channel to one of its members.
+func (s *Service) Token(ctx context.Context, conversationID, userID string) (*Credentials, error) {
+	if s.tokens == nil {
+		return nil, ErrNotConfigured
+	}
+	if _, err := s.store.GetParticipant(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	uid := UID(userID)
+	token, err := s.tokens.RTCToken(conversationID, uid, TokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credentials{
+		AppID:       s.tokens.AppID(),
+		ChannelName: conversationID,
+		Token:       token,
+		UID:         uid,
+		ExpiresAt:   s.now().Add(TokenTTL),
+	}, nil
+}
+
+// Start rings the other members of the conversation.
+func (s *Service) Start(ctx context.Context, conversationID, callerID, callType string) (*domain.Call, error) {
+	if callType != domain.CallAudio && callType != domain.CallVideo {
+		return nil, ErrInvalidCallType
+	}
+	if _, err := s.store.GetParticipant(ctx, conversationID, callerID); err != nil {
+		return nil, err
+	}
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := uuid.Parse(callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &domain.Call{
+		ID:             uuid.New(),
+		ConversationID: convID,
+		CallerID:       caller,
+		Type:           callType,
+		Status:         domain.CallRinging,
+		CreatedAt:      s.now(),
+	}
+	if err := s.store.CreateCall(ctx, call); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// ActiveCall returns the live call of a conversation, or nil.
+func (s *Service) ActiveCall(ctx context.Context, conversationID string) (*domain.Call, error) {
+	return s.store.ActiveCall(ctx, conversationID)
+}
+
+// loadForMember fetches a call and checks that the user belongs to its
+// conversation.
+func (s *Service) loadForMember(ctx context.Context, callID, userID string) (*domain.Call, *domain.Conversation, error) {
+	call, err := s.store.GetCall(ctx, callID)
+	if err != nil {
+		return nil, nil, err
+	}
+	conversationID := call.ConversationID.String()
+	if _, err := s.store.GetParticipant(ctx, conversationID, userID); err != nil {
+		return nil, nil, err
+	}
+	conv, err := s.store.GetConversation(ctx, conversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return call, conv, nil
+}
+
+// Accept answers a ringing call. In a group, members who pick up after the
+// first one simply join; the call is already accepted. The bool reports
+// whether the call's state changed.
+func (s *Service) Accept(ctx context.Context, callID, userID string) (*domain.Call, bool, error) {
+	call, conv, err := s.loadForMember(ctx, callID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if call.CallerID.String() == userID {
+		return nil, false, ErrCallerAction
+	}
+	if conv.IsGroup && call.Status == domain.CallAccepted {
+		return call, false, nil
+	}
+	return s.transition(ctx, call, []string{domain.CallRinging}, domain.CallAccepted)
+}
+
+// Decline rejects a ringing call. In a group it only dismisses the call for
+// that member, so nothing changes.
+func (s *Service) Decline(ctx context.Context, callID, userID string) (*domain.Call, bool, error) {
+	call, conv, err := s.loadForMember(ctx, callID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if call.CallerID.String() == userID {
+		return nil, false, ErrCallerAction
+	}
+	if conv.IsGroup {
+		return call, false, nil
+	}
+	return s.transition(ctx, call, []string{domain.CallRinging}, domain.CallDeclined)
+}
+
+// End hangs up. A caller giving up before anyone answered leaves a missed
+// call; a callee hanging up on a ringing direct call declines it. In a group
+// only the caller ends the call for everyone; other members just leave.
+func (s *Service) End(ctx context.Context, callID, userID string) (*domain.Call, bool, error) {
+	call, conv, err := s.loadForMember(ctx, callID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	isCaller := call.CallerID.String() == userID
+	if conv.IsGroup && !isCaller {
+		return call, false, nil
+	}
+
+	switch {
+	case call.Status == domain.CallRinging && isCaller:
+		return s.transition(ctx, call, []string{domain.CallRinging}, domain.CallMissed)
+	case call.Status == domain.CallRinging:
+		return s.transition(ctx, call, []string{domain.CallRinging}, domain.CallDeclined)
+	case call.Status == domain.CallAccepted:
+		return s.transition(ctx, call, []string{domain.CallAccepted}, domain.CallEnded)
+	}
+	return nil, false, repositories.ErrInvalidCallTransition
+}
+
+// Expire finishes a call that outlived its state: one still ringing after
+// RingTimeout becomes missed, one connected for longer than MaxCallDuration
+// is ended. Calls that are not overdue are left alone.
+func (s *Service) Expire(ctx context.Context, callID string) (*domain.Call, bool, error) {
+	call, err := s.store.GetCall(ctx, callID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := s.now()
+	switch {
+	case call.Status == domain.CallRinging && !now.Before(call.CreatedAt.Add(RingTimeout)):
+		return s.transition(ctx, call, []string{domain.CallRinging}, domain.CallMissed)
+	case call.Status == domain.CallAccepted && call.AnsweredAt != nil && !now.Before(call.AnsweredAt.Add(MaxCallDuration)):
+		return s.transition(ctx, call, []string{domain.CallAccepted}, domain.CallEnded)
+	}
+	return call, false, nil
+}
+
+func (s *Service) transition(ctx context.Context, call *domain.Call, from []string, to string) (*domain.Call, bool, error) {
+	updated, err := s.store.TransitionCall(ctx, call.ID.String(), from, to, s.now())
+	if err != nil {
+		return nil, false, err
+	}
+	return updated, true, nil
+}
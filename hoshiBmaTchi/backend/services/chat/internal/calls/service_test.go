@@ -0,0 +1,305 @@
+package calls_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+)
+
+// fakeProvider stands in for Agora and records what it was asked to sign.
+type fakeProvider struct {
+	channel string
+	uid     uint32
+	ttl     time.Duration
+}
+
+func (p *fakeProvider) AppID() string { return "test-app" }
+
+func (p *fakeProvider) RTCToken(channelName string, uid uint32, ttl time.Duration) (string, error) {
+	p.channel, p.uid, p.ttl = channelName, uid, ttl
+	return fmt.Sprintf("token:%s:%d", channelName, uid), nil
+}
+
+// memStore keeps calls in memory with the same rules as ChatRepository.
+type memStore struct {
+	conversations map[string]*domain.Conversation
+	members       map[string]map[string]bool
+	calls         map[string]*domain.Call
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		conversations: map[string]*domain.Conversation{},
+		members:       map[string]map[string]bool{},
+		calls:         map[string]*domain.Call{},
+	}
+}
+
+func (m *memStore) addConversation(isGroup bool, userIDs ...string) string {
+	id := uuid.New()
+	m.conversations[id.String()] = &domain.Conversation{ID: id, IsGroup: isGroup}
+	m.members[id.String()] = map[string]bool{}
+	for _, u := range userIDs {
+		m.members[id.String()][u] = true
+	}
+	return id.String()
+}
+
+func (m *memStore) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	conv, ok := m.conversations[conversationID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return conv, nil
+}
+
+func (m *memStore) GetParticipant(ctx context.Context, conversationID, userID string) (*domain.Participant, error) {
+	if !m.members[conversationID][userID] {
+		return nil, repositories.ErrNotParticipant
+	}
+	return &domain.Participant{UserID: uuid.MustParse(userID)}, nil
+}
+
+func (m *memStore) CreateCall(ctx context.Context, call *domain.Call) error {
+	if active, _ := m.ActiveCall(ctx, call.ConversationID.String()); active != nil {
+		return repositories.ErrCallInProgress
+	}
+	stored := *call
+	m.calls[call.ID.String()] = &stored
+	return nil
+}
+
+func (m *memStore) GetCall(ctx context.Context, callID string) (*domain.Call, error) {
+	call, ok := m.calls[callID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *call
+	return &copied, nil
+}
+
+func (m *memStore) ActiveCall(ctx context.Context, conversationID string) (*domain.Call, error) {
+	for _, call := range m.calls {
+		if call.ConversationID.String() == conversationID && call.IsActive() {
+			copied := *call
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memStore) TransitionCall(ctx context.Context, callID string, from []string, to string, at time.Time) (*domain.Call, error) {
+	call, ok := m.calls[callID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	for _, status := range from {
+		if call.Status == status {
+			call.Status = to
+			if to == domain.CallAccepted {
+				call.AnsweredAt = &at
+			} else {
+				call.EndedAt = &at
+			}
+			return m.GetCall(ctx, callID)
+		}
+	}
+	return nil, repositories.ErrInvalidCallTransition
+}
+
+var (
+	alice = uuid.NewString()
+	bob   = uuid.NewString()
+	carol = uuid.NewString()
+)
+
+func TestToken_IssuesPerUserShortLivedToken(t *testing.T) {
+	store := newMemStore()
+	convID := store.addConversation(false, alice, bob)
+	provider := &fakeProvider{}
+	svc := calls.NewService(store, provider)
+
+	creds, err := svc.Token(context.Background(), convID, alice)
+
+	require.NoError(t, err)
+	assert.Equal(t, calls.UID(alice), creds.UID)
+	assert.NotEqual(t, calls.UID(alice), calls.UID(bob))
+	assert.Equal(t, convID, provider.channel)
+	assert.Equal(t, calls.TokenTTL, provider.ttl)
+	assert.Equal(t, "test-app", creds.AppID)
+}
+
+func TestToken_RejectsNonMembers(t *testing.T) {
+	store := newMemStore()
+	convID := store.addConversation(false, alice, bob)
+	provider := &fakeProvider{}
+	svc := calls.NewService(store, provider)
+
+	_, err := svc.Token(context.Background(), convID, carol)
+
+	assert.ErrorIs(t, err, repositories.ErrNotParticipant)
+	assert.Empty(t, provider.channel, "no token should be signed for outsiders")
+}
+
+func TestToken_WithoutProvider(t *testing.T) {
+	store := newMemStore()
+	convID := store.addConversation(false, alice, bob)
+	svc := calls.NewService(store, nil)
+
+	_, err := svc.Token(context.Background(), convID, alice)
+
+	assert.ErrorIs(t, err, calls.ErrNotConfigured)
+}
+
+func TestCall_AcceptedThenEnded(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	convID := store.addConversation(false, alice, bob)
+	svc := calls.NewService(store, &fakeProvider{})
+
+	call, err := svc.Start(ctx, convID, alice, domain.CallVideo)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CallRinging, call.Status)
+
+	_, err = svc.Start(ctx, convID, bob, domain.CallAudio)
+	assert.ErrorIs(t, err, repositories.ErrCallInProgress)
+
+	_, _, err = svc.Accept(ctx, call.ID.String(), alice)
+	assert.ErrorIs(t, err, calls.ErrCallerAction)
+
+	call, changed, err := svc.Accept(ctx, call.ID.String(), bob)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, domain.CallAccepted, call.Status)
+
+	call, changed, err = svc.End(ctx, call.ID.String(), bob)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, domain.CallEnded, call.Status)
+	assert.False(t, call.IsActive())
+
+	_, _, err = svc.End(ctx, call.ID.String(), alice)
+	assert.ErrorIs(t, err, repositories.ErrInvalidCallTransition)
+}
+
+func TestCall_RingingOutcomes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		action func(svc *calls.Service, callID string) (*domain.Call, bool, error)
+		want   string
+	}{
+		{
+			name: "callee declines",
+			action: func(svc *calls.Service, callID string) (*domain.Call, bool, error) {
+				return svc.Decline(ctx, callID, bob)
+			},
+			want: domain.CallDeclined,
+		},
+		{
+			name: "callee hangs up",
+			action: func(svc *calls.Service, callID string) (*domain.Call, bool, error) {
+				return svc.End(ctx, callID, bob)
+			},
+			want: domain.CallDeclined,
+		},
+		{
+			name: "caller gives up",
+			action: func(svc *calls.Service, callID string) (*domain.Call, bool, error) {
+				return svc.End(ctx, callID, alice)
+			},
+			want: domain.CallMissed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore()
+			convID := store.addConversation(false, alice, bob)
+			svc := calls.NewService(store, &fakeProvider{})
+
+			call, err := svc.Start(ctx, convID, alice, domain.CallAudio)
+			require.NoError(t, err)
+
+			call, changed, err := tt.action(svc, call.ID.String())
+			require.NoError(t, err)
+			assert.True(t, changed)
+			assert.Equal(t, tt.want, call.Status)
+		})
+	}
+}
+
+func TestCall_GroupMembersLeaveWithoutEndingIt(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	convID := store.addConversation(true, alice, bob, carol)
+	svc := calls.NewService(store, &fakeProvider{})
+
+	call, err := svc.Start(ctx, convID, alice, domain.CallVideo)
+	require.NoError(t, err)
+
+	_, changed, err := svc.Decline(ctx, call.ID.String(), carol)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	_, changed, err = svc.Accept(ctx, call.ID.String(), bob)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	// A second member joining an accepted group call is not a transition.
+	call, changed, err = svc.Accept(ctx, call.ID.String(), carol)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, domain.CallAccepted, call.Status)
+
+	_, changed, err = svc.End(ctx, call.ID.String(), bob)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	call, changed, err = svc.End(ctx, call.ID.String(), alice)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, domain.CallEnded, call.Status)
+}
+
+func TestCall_ExpireOnlyAfterRingTimeout(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	convID := store.addConversation(false, alice, bob)
+	svc := calls.NewService(store, &fakeProvider{})
+
+	call, err := svc.Start(ctx, convID, alice, domain.CallAudio)
+	require.NoError(t, err)
+
+	_, changed, err := svc.Expire(ctx, call.ID.String())
+	require.NoError(t, err)
+	assert.False(t, changed, "a fresh call keeps ringing")
+
+	store.calls[call.ID.String()].CreatedAt = time.Now().Add(-calls.RingTimeout)
+
+	call, changed, err = svc.Expire(ctx, call.ID.String())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, domain.CallMissed, call.Status)
+}
+
+func TestStart_RejectsUnknownType(t *testing.T) {
+	store := newMemStore()
+	convID := store.addConversation(false, alice, bob)
+	svc := calls.NewService(store, &fakeProvider{})
+
+	_, err := svc.Start(context.Background(), convID, alice, "hologram")
+
+	assert.ErrorIs(t, err, calls.ErrInvalidCallType)
+}
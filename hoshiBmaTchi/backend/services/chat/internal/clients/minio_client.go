@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MinioClient talks to the chat media bucket. The bucket is private: objects
+// are only reachable through presigned URLs handed out to participants.
+type MinioClient struct {
+	client     *minio.Client
+	signer     *minio.Client
+	bucketName string
+}
+
+// NewMinioClient connects to MinIO on endpoint. publicEndpoint is the base URL
+// browsers use to reach the same server; presigned URLs are signed for that
+// host because the signature covers it.
+func NewMinioClient(endpoint, publicEndpoint, accessKey, secretKey, bucketName string, useSSL bool) (*MinioClient, error) {
+	creds := credentials.NewStaticV4(accessKey, secretKey, "")
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	}
+
+	signer := client
+	if publicEndpoint != "" {
+		public, err := url.Parse(publicEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public endpoint: %w", err)
+		}
+		// Signing is local, but without a region the client would ask the
+		// public host for the bucket location, which the service cannot reach.
+		signer, err = minio.New(public.Host, &minio.Options{
+			Creds:  creds,
+			Secure: public.Scheme == "https",
+			Region: "us-east-1",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create minio signer: %w", err)
+		}
+	}
+
+	ctx := context.Background()
+	exists, err := client.BucketExists(ctx, bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to create bucket: %w", err)
+		}
+		log.Printf("Created bucket: %s", bucketName)
+	}
+
+	log.Printf("Connected to MinIO at %s, bucket: %s", endpoint, bucketName)
+
+	return &MinioClient{client: client, signer: signer, bucketName: bucketName}, nil
+}
+
+func (m *MinioClient) Upload(ctx context.Context, objectName string, r io.Reader, size int64, contentType string) error {
+	_, err := m.client.PutObject(ctx, m.bucketName, objectName, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+	return nil
+}
+
+// PresignedURL returns a temporary download link. A non-empty downloadName
+// makes browsers save the object instead of rendering it.
+func (m *MinioClient) PresignedURL(ctx context.Context, objectName, downloadName string, expires time.Duration) (string, error) {
+	params := url.Values{}
+	if downloadName != "" {
+		params.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", downloadName))
+	}
+
+	presignedURL, err := m.signer.PresignedGetObject(ctx, m.bucketName, objectName, expires, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned URL: %w", err)
+	}
+	return presignedURL.String(), nil
+}
+
+// Download opens an object for reading. The caller closes it.
+func (m *MinioClient) Download(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	obj, err := m.client.GetObject(ctx, m.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	return obj, nil
+}
+
+func (m *MinioClient) Remove(ctx context.Context, objectName string) error {
+	if err := m.client.RemoveObject(ctx, m.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// blockCacheTTL bounds how long a block or unblock takes to reach chat.
+const blockCacheTTL = 30 * time.Second
+
+// maxCachedEntries caps each cache; past it, expired entries are swept.
+const maxCachedEntries = 10000
+
+// expiring is what the cache entries have in common.
+type expiring interface {
+	expiresAt() time.Time
+}
+
+func (e blockedEntry) expiresAt() time.Time   { return e.expires }
+func (e relationsEntry) expiresAt() time.Time { return e.expires }
+func (e profileEntry) expiresAt() time.Time   { return e.expires }
+
+// store puts an entry in a cache, first sweeping expired entries if the
+// cache is full. Callers hold c.mu.
+func store[E expiring](cache map[string]E, key string, entry E) {
+	if len(cache) >= maxCachedEntries {
+		now := time.Now()
+		for k, e := range cache {
+			if now.After(e.expiresAt()) {
+				delete(cache, k)
+			}
+		}
+	}
+	cache[key] = entry
+}
+
+type blockedEntry struct {
+	blocked bool
+	expires time.Time
+}
+
+type relationsEntry struct {
+	userIDs map[string]bool
+	expires time.Time
+}
+
+// profileCacheTTL is longer than the block TTL: a stale display name in a
+// notification is harmless.
+const profileCacheTTL = 5 * time.Minute
+
+// maxProfileBatch matches the users service's limit for GetUserProfiles.
+const maxProfileBatch = 500
+
+// UserProfile is the part of a profile chat shows next to messages.
+type UserProfile struct {
+	ID                string
+	Username          string
+	Name              string
+	ProfilePictureURL string
+}
+
+type profileEntry struct {
+	profile UserProfile
+	expires time.Time
+}
+
+// UserServiceClient answers the block, follow and profile questions
+// chat needs from the users service. Lookups are cached briefly since they
+// run on every send. A nil client allows everything, so chat keeps working
+// without it.
+type UserServiceClient struct {
+	client pb.UserServiceClient
+	conn   *grpc.ClientConn
+
+	mu        sync.Mutex
+	blocked   map[string]blockedEntry
+	relations map[string]relationsEntry
+	profiles  map[string]profileEntry
+}
+
+func NewUserServiceClient(address string) (*UserServiceClient, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                10 * time.Second,
+			Timeout:             3 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}
+
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	log.Printf("Connected to user service at %s", address)
+
+	return &UserServiceClient{
+		client:    pb.NewUserServiceClient(conn),
+		conn:      conn,
+		blocked:   make(map[string]blockedEntry),
+		relations: make(map[string]relationsEntry),
+		profiles:  make(map[string]profileEntry),
+	}, nil
+}
+
+func pairKey(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + ":" + b
+}
+
+// IsBlocked reports whether either user blocked the other.
+func (c *UserServiceClient) IsBlocked(ctx context.Context, userID, otherID string) (bool, error) {
+	if c == nil || userID == otherID {
+		return false, nil
+	}
+
+	key := pairKey(userID, otherID)
+	c.mu.Lock()
+	entry, ok := c.blocked[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.blocked, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.IsBlocked(ctx, &pb.IsBlockedRequest{UserId: userID, TargetId: otherID})
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	store(c.blocked, key, blockedEntry{blocked: resp.IsBlocked, expires: time.Now().Add(blockCacheTTL)})
+	c.mu.Unlock()
+	return resp.IsBlocked, nil
+}
+
+// BlockedUserIDs returns everyone the user blocked or was blocked by.
+func (c *UserServiceClient) BlockedUserIDs(ctx context.Context, userID string) (map[string]bool, error) {
+	if c == nil {
+		return map[string]bool{}, nil
+	}
+
+	c.mu.Lock()
+	entry, ok := c.relations[userID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.userIDs, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetBlockRelations(ctx, &pb.GetBlockRelationsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(resp.BlockedIds)+len(resp.BlockedByIds))
+	for _, id := range resp.BlockedIds {
+		ids[id] = true
+	}
+	for _, id := range resp.BlockedByIds {
+		ids[id] = true
+	}
+
+	c.mu.Lock()
+	store(c.relations, userID, relationsEntry{userIDs: ids, expires: time.Now().Add(blockCacheTTL)})
+	c.mu.Unlock()
+	return ids, nil
+}
+
+// NeedsMessageRequest reports whether a new conversation from sender should
+// wait in the recipient's message requests. That is the case when the
+// recipient's account is private and neither of them follows the other.
+func (c *UserServiceClient) NeedsMessageRequest(ctx context.Context, senderID, recipientID string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetRelationship(ctx, &pb.GetRelationshipRequest{UserId: senderID, TargetId: recipientID})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.TargetIsPrivate && !resp.IsFollowing && !resp.IsFollowedBy, nil
+}
+
+// GetUserProfile returns the display details of a user. With no users service
+// it returns an empty profile carrying just the ID.
+func (c *UserServiceClient) GetUserProfile(ctx context.Context, userID string) (UserProfile, error) {
+	if c == nil {
+		return UserProfile{ID: userID}, nil
+	}
+
+	c.mu.Lock()
+	entry, ok := c.profiles[userID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.profile, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetUserProfile(ctx, &pb.GetUserProfileRequest{UserId: userID, ViewerId: userID})
+	if err != nil {
+		return UserProfile{ID: userID}, err
+	}
+
+	profile := UserProfile{
+		ID:                userID,
+		Username:          resp.Username,
+		Name:              resp.Name,
+		ProfilePictureURL: resp.ProfilePictureUrl,
+	}
+	c.mu.Lock()
+	store(c.profiles, userID, profileEntry{profile: profile, expires: time.Now().Add(profileCacheTTL)})
+	c.mu.Unlock()
+	return profile, nil
+}
+
+// GetUserProfiles returns the display details of several users, keyed by ID,
+// with one users-service call for those not cached. Users that do not exist
+// are missing from the result. With no users service it returns empty
+// profiles carrying just the IDs.
+func (c *UserServiceClient) GetUserProfiles(ctx context.Context, userIDs []string) (map[string]UserProfile, error) {
+	profiles := make(map[string]UserProfile, len(userIDs))
+	if c == nil {
+		for _, id := range userIDs {
+			profiles[id] = UserProfile{ID: id}
+		}
+		return profiles, nil
+	}
+
+	var missing []string
+	queued := make(map[string]bool)
+	now := time.Now()
+	c.mu.Lock()
+	for _, id := range userIDs {
+		if entry, ok := c.profiles[id]; ok && now.Before(entry.expires) {
+			profiles[id] = entry.profile
+		} else if !queued[id] {
+			queued[id] = true
+			missing = append(missing, id)
+		}
+	}
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > maxProfileBatch {
+			batch = batch[:maxProfileBatch]
+		}
+		missing = missing[len(batch):]
+
+		resp, err := c.client.GetUserProfiles(ctx, &pb.GetUserProfilesRequest{UserIds: batch})
+		if err != nil {
+			return profiles, err
+		}
+
+		expires := time.Now().Add(profileCacheTTL)
+		c.mu.Lock()
+		for _, u := range resp.Users {
+			profile := UserProfile{
+				ID:                u.UserId,
+				Username:          u.Username,
+				Name:              u.Name,
+				ProfilePictureURL: u.ProfilePictureUrl,
+			}
+			profiles[u.UserId] = profile
+			store(c.profiles, u.UserId, profileEntry{profile: profile, expires: expires})
+		}
+		c.mu.Unlock()
+	}
+	return profiles, nil
+}
+
+// GetUserEmail returns the address the user's account emails go to and the
+// language they are written in. It is not cached; only infrequent jobs such
+// as exports need it. With no users service it returns "".
+func (c *UserServiceClient) GetUserEmail(ctx context.Context, userID string) (email, locale string, err error) {
+	if c == nil {
+		return "", "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetUserEmail(ctx, &pb.GetUserEmailRequest{UserId: userID})
+	if err != nil {
+		return "", "", err
+	}
+	return resp.Email, resp.Locale, nil
+}
+
+func (c *UserServiceClient) Close() error {
+	if c != nil && c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CallAudio = "audio"
+	CallVideo = "video"
+)
+
+// A call starts ringing and ends in exactly one of declined, missed or ended.
+// Accepted is the only other live state.
+const (
+	CallRinging  = "ringing"
+	CallAccepted = "accepted"
+	CallDeclined = "declined"
+	CallMissed   = "missed"
+	CallEnded    = "ended"
+)
+
+// MediaTypeCall marks the call-log message posted when a call finishes. Its
+// content is a JSON CallLog.
+const MediaTypeCall = "call"
+
+type Call struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ConversationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"conversation_id"`
+	CallerID       uuid.UUID  `gorm:"type:uuid;not null" json:"caller_id"`
+	Type           string     `gorm:"not null" json:"type"`
+	Status         string     `gorm:"not null;index" json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AnsweredAt     *time.Time `json:"answered_at,omitempty"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+}
+
+func (c Call) IsActive() bool {
+	return c.Status == CallRinging || c.Status == CallAccepted
+}
+
+// Duration is how long the call was connected; zero if it never was.
+func (c Call) Duration() time.Duration {
+	if c.AnsweredAt == nil || c.EndedAt == nil {
+		return 0
+	}
+	return c.EndedAt.Sub(*c.AnsweredAt)
+}
+
+type CallLog struct {
+	CallID          string `json:"call_id"`
+	CallType        string `json:"call_type"`
+	Status          string `json:"status"`
+	DurationSeconds int64  `json:"duration_seconds,omitempty"`
+}
+
+func NewCallLog(c Call) CallLog {
+	return CallLog{
+		CallID:          c.ID.String(),
+		CallType:        c.Type,
+		Status:          c.Status,
+		DurationSeconds: int64(c.Duration() / time.Second),
+	}
+}
+
+func callPreview(content string) string {
+	var log CallLog
+	if err := json.Unmarshal([]byte(content), &log); err != nil {
+		return "Call"
+	}
+
+	kind := "audio call"
+	if log.CallType == CallVideo {
+		kind = "video call"
+	}
+	switch {
+	case log.Status == CallMissed:
+		return "Missed " + kind
+	case log.Status == CallDeclined:
+		return "Declined " + kind
+	case log.DurationSeconds > 0:
+		return fmt.Sprintf("Ended %s · %d:%02d", kind, log.DurationSeconds/60, log.DurationSeconds%60)
+	}
+	return "Ended " + kind
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserEvent is one entry in a user's event log: a frame that was delivered
+// to them, kept so a client that was offline can catch up. Seq counts up by
+// one per event for each user.
+type UserEvent struct {
+	UserID         uuid.UUID  `gorm:"type:uuid;primaryKey" json:"-"`
+	Seq            int64      `gorm:"primaryKey;autoIncrement:false" json:"seq"`
+	ConversationID *uuid.UUID `gorm:"type:uuid;index" json:"conversation_id,omitempty"`
+	MessageID      *uuid.UUID `gorm:"type:uuid;index" json:"-"`
+	Type           string     `gorm:"not null" json:"type"`
+	Payload        string     `gorm:"type:text;not null" json:"-"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+}
+
+// UserEventSequence holds the last sequence number handed out to a user.
+// Its row lock orders concurrent writers to the same log.
+type UserEventSequence struct {
+	UserID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	LastSeq int64     `gorm:"not null;default:0"`
+}
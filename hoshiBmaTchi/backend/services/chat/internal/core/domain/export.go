@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// An export job is pending until a worker picks it up, then running, then
+// either completed or failed.
+const (
+	ExportPending   = "pending"
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// ExportJob is a user's request for an archive of their conversations.
+type ExportJob struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status      string     `gorm:"not null;index" json:"status"`
+	ObjectKey   string     `json:"-"`
+	Size        int64      `json:"size,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// ExpiresAt is when the archive is deleted and can no longer be
+	// downloaded.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (j ExportJob) IsDone() bool {
+	return j.Status == ExportCompleted || j.Status == ExportFailed
+}
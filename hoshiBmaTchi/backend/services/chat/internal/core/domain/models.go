@@ -1,34 +1,223 @@
 package domain
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
+
 	"github.com/google/uuid"
 )
 
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+// MediaTypeSystem marks messages generated by the service, such as a member
+// joining or the group being renamed. Their content is a JSON SystemEvent.
+const MediaTypeSystem = "system"
+
 type Conversation struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name      string    `json:"name"`
-	IsGroup   bool      `gorm:"default:false" json:"is_group"`
-	CreatedAt time.Time `json:"created_at"`
-	
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name      string     `json:"name"`
+	AvatarURL string     `json:"avatar_url"`
+	IsGroup   bool       `gorm:"default:false" json:"is_group"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// DisappearAfter is the disappearing-messages timer in seconds; 0 is off.
+	// It applies to messages sent after it was set.
+	DisappearAfter int64 `gorm:"default:0" json:"disappear_after"`
+
 	Participants []Participant `gorm:"foreignKey:ConversationID" json:"participants"`
 	Messages     []Message     `gorm:"foreignKey:ConversationID" json:"messages"`
+
+	LastMessage   string     `gorm:"-" json:"last_message"`
+	LastMessageAt *time.Time `gorm:"-" json:"last_message_at"`
+	UnreadCount   int64      `gorm:"-" json:"unread_count"`
+
+	// The caller's own inbox settings, copied from their participant row.
+	IsPinned   bool       `gorm:"-" json:"is_pinned"`
+	IsArchived bool       `gorm:"-" json:"is_archived"`
+	MutedUntil *time.Time `gorm:"-" json:"muted_until,omitempty"`
+
+	// How the conversation is shown to the caller: the group's name and
+	// picture, or the other person's in a direct conversation.
+	DisplayName      string `gorm:"-" json:"display_name"`
+	DisplayAvatarURL string `gorm:"-" json:"display_avatar_url"`
 }
 
 type Participant struct {
 	ConversationID    uuid.UUID  `gorm:"type:uuid;primaryKey" json:"conversation_id"`
-	UserID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"` 
+	UserID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
 	JoinedAt          time.Time  `json:"joined_at"`
 	LastReadMessageID *uuid.UUID `gorm:"type:uuid" json:"last_read_message_id"`
+	Role              string     `gorm:"default:'member'" json:"role"`
+
+	// HiddenAt is set when the user deletes the conversation for themselves.
+	// Messages up to that point stay hidden for them; the conversation shows
+	// up again once someone sends a newer message.
+	HiddenAt *time.Time `json:"hidden_at,omitempty"`
+
+	// IsRequest keeps a direct conversation in the user's message requests
+	// instead of their inbox until they accept it or reply.
+	IsRequest bool `gorm:"default:false" json:"is_request"`
+
+	// Inbox settings are private to the participant, so they are left out of
+	// the participant list other members see.
+	MutedUntil *time.Time `json:"-"`
+	IsPinned   bool       `gorm:"default:false" json:"-"`
+	IsArchived bool       `gorm:"default:false" json:"-"`
+
+	// Profile details from the users service, filled in for conversation
+	// lists so clients need not look every member up.
+	Username          string `gorm:"-" json:"username,omitempty"`
+	Name              string `gorm:"-" json:"name,omitempty"`
+	ProfilePictureURL string `gorm:"-" json:"profile_picture_url,omitempty"`
+}
+
+// MutedForever is stored as MutedUntil for mutes without an end.
+var MutedForever = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// IsMuted reports whether notifications for the conversation are silenced
+// at the given time.
+func (p Participant) IsMuted(at time.Time) bool {
+	return p.MutedUntil != nil && p.MutedUntil.After(at)
+}
+
+func (p Participant) IsAdmin() bool {
+	return p.Role == RoleOwner || p.Role == RoleAdmin
 }
 
 type Message struct {
-	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	ConversationID uuid.UUID `gorm:"type:uuid;not null" json:"conversation_id"`
-	SenderID       uuid.UUID `gorm:"type:uuid;not null" json:"sender_id"`
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey;index:idx_messages_history,priority:3" json:"id"`
+	ConversationID uuid.UUID `gorm:"type:uuid;not null;index:idx_messages_history,priority:1" json:"conversation_id"`
+	SenderID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_messages_client_msg,priority:1" json:"sender_id"`
 	Content        string    `json:"content"`
 	MediaURL       string    `json:"media_url"`
-	MediaType      string    `json:"media_type"` 
+	MediaType      string    `json:"media_type"`
 	IsUnsent       bool      `gorm:"default:false" json:"is_unsent"`
-	CreatedAt      time.Time `json:"created_at"`
-}
\ No newline at end of file
+	CreatedAt      time.Time `gorm:"index:idx_messages_history,priority:2" json:"created_at"`
+
+	ReplyToID *uuid.UUID        `gorm:"type:uuid" json:"reply_to_id,omitempty"`
+	EditedAt  *time.Time        `json:"edited_at,omitempty"`
+	Reactions []MessageReaction `gorm:"foreignKey:MessageID" json:"reactions,omitempty"`
+
+	AttachmentID *uuid.UUID  `gorm:"type:uuid" json:"attachment_id,omitempty"`
+	Attachment   *Attachment `gorm:"foreignKey:AttachmentID" json:"attachment,omitempty"`
+
+	// ExpiresAt is set on messages sent while disappearing messages are on.
+	ExpiresAt *time.Time `gorm:"index" json:"expires_at,omitempty"`
+
+	// ClientMsgID is the sender's own ID for the message. A resend with the
+	// same ID returns the stored message instead of posting it twice.
+	ClientMsgID *string `gorm:"size:64;uniqueIndex:idx_messages_client_msg,priority:2" json:"client_msg_id,omitempty"`
+}
+
+// DisappearingModes are the timers a conversation can choose from.
+var DisappearingModes = map[string]time.Duration{
+	"off": 0,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"90d": 90 * 24 * time.Hour,
+}
+
+const (
+	AttachmentImage = "image"
+	AttachmentVideo = "video"
+	AttachmentAudio = "audio"
+	AttachmentFile  = "file"
+)
+
+// Attachment is an uploaded file in the private chat bucket. It belongs to
+// the uploader until a message claims it; after that anyone in the message's
+// conversation can get a presigned URL for it.
+type Attachment struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UploaderID uuid.UUID  `gorm:"type:uuid;not null;index" json:"uploader_id"`
+	MessageID  *uuid.UUID `gorm:"type:uuid;index" json:"message_id,omitempty"`
+	ObjectKey  string     `gorm:"not null" json:"-"`
+	Kind       string     `gorm:"not null" json:"kind"`
+	MimeType   string     `json:"mime_type"`
+	FileName   string     `json:"file_name,omitempty"`
+	Size       int64      `json:"size"`
+	DurationMs int64      `json:"duration_ms,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// MessageReaction is a single user's emoji on a message. A user has at most
+// one reaction per message; reacting again replaces it.
+type MessageReaction struct {
+	MessageID uuid.UUID `gorm:"type:uuid;primaryKey" json:"message_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Emoji     string    `gorm:"not null" json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MessageDelivery records that a message reached one of the recipient's
+// sockets.
+type MessageDelivery struct {
+	MessageID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"message_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+// MessageEdit keeps the content a message had before an edit.
+type MessageEdit struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	MessageID       uuid.UUID `gorm:"type:uuid;not null;index" json:"message_id"`
+	PreviousContent string    `json:"previous_content"`
+	EditedAt        time.Time `json:"edited_at"`
+}
+
+func (m Message) IsShare() bool {
+	return strings.HasSuffix(m.MediaType, "_share")
+}
+
+type SystemEvent struct {
+	Event   string `json:"event"`
+	ActorID string `json:"actor_id"`
+	UserID  string `json:"user_id,omitempty"`
+	Value   string `json:"value,omitempty"`
+}
+
+var systemEventPreviews = map[string]string{
+	"participant_added":    "A member was added",
+	"participant_removed":  "A member was removed",
+	"participant_left":     "A member left the group",
+	"role_changed":         "Group admins changed",
+	"owner_changed":        "Group owner changed",
+	"renamed":              "Group name changed",
+	"avatar_changed":       "Group photo changed",
+	"disappearing_changed": "Disappearing messages changed",
+}
+
+// Preview is the short text shown for a message in the conversation list.
+func (m Message) Preview() string {
+	switch m.MediaType {
+	case MediaTypeSystem:
+		var event SystemEvent
+		if err := json.Unmarshal([]byte(m.Content), &event); err == nil {
+			if text, ok := systemEventPreviews[event.Event]; ok {
+				return text
+			}
+		}
+		return "Conversation updated"
+	case MediaTypeCall:
+		return callPreview(m.Content)
+	case "story_share":
+		return "Shared a story"
+	case "post_share":
+		return "Shared a post"
+	case "reel_share":
+		return "Shared a reel"
+	}
+	if m.Content == "" && (m.MediaURL != "" || m.AttachmentID != nil) {
+		if m.MediaType == AttachmentAudio {
+			return "Sent a voice message"
+		}
+		return "Sent a " + m.MediaType
+	}
+	return m.Content
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/export"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type exportResponse struct {
+	domain.ExportJob
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// RequestExport queues an archive of all the caller's conversations. The
+// archive is emailed when ready; its status can be polled meanwhile.
+func (h *ChatHandler) RequestExport(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	job, err := h.Exports.Request(c, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, export.ErrNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		case errors.Is(err, repositories.ErrExportInProgress):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start export"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, exportResponse{ExportJob: *job})
+}
+
+func (h *ChatHandler) ListExports(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	jobs, err := h.Exports.Jobs(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch exports"})
+		return
+	}
+
+	resp := make([]exportResponse, 0, len(jobs))
+	for i := range jobs {
+		url, _ := h.Exports.DownloadURL(c, &jobs[i])
+		resp = append(resp, exportResponse{ExportJob: jobs[i], DownloadURL: url})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *ChatHandler) GetExport(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	jobID := c.Param("jobId")
+	if _, err := uuid.Parse(jobID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		return
+	}
+
+	job, url, err := h.Exports.Job(c, jobID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch export"})
+		return
+	}
+
+	c.JSON(http.StatusOK, exportResponse{ExportJob: *job, DownloadURL: url})
+}
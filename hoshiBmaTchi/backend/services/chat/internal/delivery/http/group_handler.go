@@ -0,0 +1,234 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type UpdateGroupRequest struct {
+	Name      *string `json:"name"`
+	AvatarURL *string `json:"avatar_url"`
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// writeGroupError maps the group rules enforced by the repository onto HTTP
+// status codes.
+func writeGroupError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conversation not found"})
+	case errors.Is(err, repositories.ErrNotParticipant),
+		errors.Is(err, repositories.ErrNotAdmin),
+		errors.Is(err, repositories.ErrNotOwner),
+		errors.Is(err, repositories.ErrCannotRemoveOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrAlreadyParticipant),
+		errors.Is(err, repositories.ErrNoMessageRequest):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repositories.ErrNotGroup),
+		errors.Is(err, repositories.ErrUseLeave),
+		errors.Is(err, repositories.ErrInvalidRole),
+		errors.Is(err, repositories.ErrInvalidGroupName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+// announce sends a structured frame to the conversation (and to extra users,
+// e.g. someone who was just removed) and records the change as a system
+// message. Delivery problems are logged; the change itself already happened.
+func (h *ChatHandler) announce(c *gin.Context, conversationID string, frame map[string]interface{}, event domain.SystemEvent, extraUserIDs ...string) {
+	frame["conversation_id"] = conversationID
+	if err := h.Hub.PublishToConversation(c, conversationID, frame, extraUserIDs...); err != nil {
+		log.Printf("Failed to announce %s in conversation %s: %v", frame["type"], conversationID, err)
+	}
+
+	if _, err := h.Hub.PostSystemEvent(c, conversationID, event); err != nil {
+		log.Printf("Failed to post %s event in conversation %s: %v", event.Event, conversationID, err)
+	}
+}
+
+func (h *ChatHandler) AddParticipant(c *gin.Context) {
+	conversationID := c.Param("id")
+	actorID := c.GetHeader("X-User-ID")
+	if actorID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req ParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Hub.CheckBlocked(c, actorID, req.UserID); err != nil {
+		writeBlockError(c, err)
+		return
+	}
+
+	if err := h.Repo.AddParticipant(c, conversationID, actorID, req.UserID); err != nil {
+		writeGroupError(c, err, "Failed to add participant")
+		return
+	}
+
+	h.Hub.InvalidateParticipants(conversationID)
+
+	h.announce(c, conversationID,
+		map[string]interface{}{"type": "participant_added", "user_id": req.UserID, "added_by": actorID},
+		domain.SystemEvent{Event: "participant_added", ActorID: actorID, UserID: req.UserID},
+	)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Participant added"})
+}
+
+func (h *ChatHandler) RemoveParticipant(c *gin.Context) {
+	conversationID := c.Param("id")
+	actorID := c.GetHeader("X-User-ID")
+	if actorID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req ParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Repo.RemoveParticipant(c, conversationID, actorID, req.UserID); err != nil {
+		writeGroupError(c, err, "Failed to remove participant")
+		return
+	}
+
+	h.Hub.InvalidateParticipants(conversationID)
+
+	h.announce(c, conversationID,
+		map[string]interface{}{"type": "participant_removed", "user_id": req.UserID, "removed_by": actorID},
+		domain.SystemEvent{Event: "participant_removed", ActorID: actorID, UserID: req.UserID},
+		req.UserID,
+	)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Participant removed"})
+}
+
+func (h *ChatHandler) SetParticipantRole(c *gin.Context) {
+	conversationID := c.Param("id")
+	targetID := c.Param("userId")
+	actorID := c.GetHeader("X-User-ID")
+	if actorID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Repo.SetParticipantRole(c, conversationID, actorID, targetID, req.Role); err != nil {
+		writeGroupError(c, err, "Failed to change role")
+		return
+	}
+
+	h.announce(c, conversationID,
+		map[string]interface{}{"type": "participant_role_changed", "user_id": targetID, "role": req.Role},
+		domain.SystemEvent{Event: "role_changed", ActorID: actorID, UserID: targetID, Value: req.Role},
+	)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role updated", "role": req.Role})
+}
+
+func (h *ChatHandler) UpdateGroup(c *gin.Context) {
+	conversationID := c.Param("id")
+	actorID := c.GetHeader("X-User-ID")
+	if actorID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Name == nil && req.AvatarURL == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "name or avatar_url is required"})
+		return
+	}
+
+	conv, err := h.Repo.UpdateGroupDetails(c, conversationID, actorID, req.Name, req.AvatarURL)
+	if err != nil {
+		writeGroupError(c, err, "Failed to update group")
+		return
+	}
+
+	frame := map[string]interface{}{
+		"type":       "conversation_updated",
+		"name":       conv.Name,
+		"avatar_url": conv.AvatarURL,
+		"updated_by": actorID,
+	}
+	if req.Name != nil {
+		h.announce(c, conversationID, frame,
+			domain.SystemEvent{Event: "renamed", ActorID: actorID, Value: conv.Name})
+	}
+	if req.AvatarURL != nil {
+		h.announce(c, conversationID, frame,
+			domain.SystemEvent{Event: "avatar_changed", ActorID: actorID, Value: conv.AvatarURL})
+	}
+
+	c.JSON(http.StatusOK, conv)
+}
+
+// LeaveGroup takes the caller out of a group for good, unlike
+// DeleteConversation which only hides it from their list.
+func (h *ChatHandler) LeaveGroup(c *gin.Context) {
+	conversationID := c.Param("id")
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	newOwnerID, objectKeys, err := h.Repo.LeaveConversation(c, conversationID, userID)
+	if err != nil {
+		writeGroupError(c, err, "Failed to leave group")
+		return
+	}
+	h.Hub.RemoveObjects(c, objectKeys)
+
+	h.Hub.InvalidateParticipants(conversationID)
+
+	remaining, err := h.Repo.GetParticipantIDs(c, conversationID)
+	if err != nil || len(remaining) == 0 {
+		// The group was deleted along with its last member.
+		c.JSON(http.StatusOK, gin.H{"message": "Left group"})
+		return
+	}
+
+	h.announce(c, conversationID,
+		map[string]interface{}{"type": "participant_left", "user_id": userID},
+		domain.SystemEvent{Event: "participant_left", ActorID: userID, UserID: userID},
+		userID,
+	)
+	if newOwnerID != "" {
+		h.announce(c, conversationID,
+			map[string]interface{}{"type": "participant_role_changed", "user_id": newOwnerID, "role": domain.RoleOwner},
+			domain.SystemEvent{Event: "owner_changed", ActorID: userID, UserID: newOwnerID},
+		)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left group", "new_owner_id": newOwnerID})
+}
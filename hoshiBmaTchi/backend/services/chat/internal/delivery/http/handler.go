@@ -1,24 +1,25 @@
 package http
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
+	"log"
+	"math"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/chat"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/export"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/media"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/ws"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
-	"github.com/AgoraIO/Tools/DynamicKey/AgoraDynamicKey/go/src/rtctokenbuilder2"
+	"gorm.io/gorm"
 )
 
 type CreateGroupRequest struct {
@@ -26,6 +27,10 @@ type CreateGroupRequest struct {
 	UserIDs []string `json:"user_ids" binding:"required"`
 }
 
+type MarkReadRequest struct {
+	MessageID string `json:"message_id"`
+}
+
 type ParticipantRequest struct {
 	UserID string `json:"user_id" binding:"required"`
 }
@@ -33,6 +38,8 @@ type ParticipantRequest struct {
 type ChatHandler struct {
 	Repo *repositories.ChatRepository
 	Hub  *ws.Hub
+	Media *media.Store
+	Exports *export.Service
 	client pb.ChatServiceClient
 }
 
@@ -43,8 +50,8 @@ type ShareContentRequest struct {
 	Thumbnail   string `json:"thumbnail"`
 }
 
-func NewChatHandler(repo *repositories.ChatRepository, hub *ws.Hub) *ChatHandler {
-	return &ChatHandler{Repo: repo, Hub: hub}
+func NewChatHandler(repo *repositories.ChatRepository, hub *ws.Hub, store *media.Store, exports *export.Service) *ChatHandler {
+	return &ChatHandler{Repo: repo, Hub: hub, Media: store, Exports: exports}
 }
 
 func (h *ChatHandler) RegisterRoutes(r *gin.Engine){
@@ -57,12 +64,26 @@ func (h *ChatHandler) RegisterRoutes(r *gin.Engine){
 		chatGroup.POST("/share", h.ShareContent)
 
 		chatGroup.GET("/:id/messages", h.GetMessageHistory)
+		chatGroup.POST("/:id/read", h.MarkAsRead)
 		chatGroup.GET("/search", h.SearchMessages) 
+		chatGroup.GET("/presence", h.GetPresence)
+		chatGroup.GET("/sync", h.Sync)
+
+		chatGroup.POST("/exports", h.RequestExport)
+		chatGroup.GET("/exports", h.ListExports)
+		chatGroup.GET("/exports/:jobId", h.GetExport)
 
 		chatGroup.GET("/:id/call-token", h.GenerateCallToken)
 
 		chatGroup.POST("/:id/participants", h.AddParticipant)
 		chatGroup.DELETE("/:id/participants", h.RemoveParticipant)
+		chatGroup.PUT("/:id/participants/:userId/role", h.SetParticipantRole)
+		chatGroup.PATCH("/:id", h.UpdateGroup)
+		chatGroup.POST("/:id/leave", h.LeaveGroup)
+		chatGroup.POST("/:id/accept", h.AcceptRequest)
+		chatGroup.POST("/:id/decline", h.DeclineRequest)
+		chatGroup.PUT("/:id/settings", h.UpdateConversationSettings)
+		chatGroup.PUT("/:id/disappearing", h.SetDisappearingMessages)
 
 		chatGroup.POST("/upload", h.UploadMedia) 
         chatGroup.DELETE("/:id", h.DeleteConversation)
@@ -92,11 +113,17 @@ func (h *ChatHandler) GetConversations(c *gin.Context) {
 		return
 	}
 
-	convs, err := h.Repo.GetConversations(c, userID)
+	filter := repositories.ConversationFilter{
+		Requests: c.Query("requests") == "true",
+		Archived: c.Query("archived") == "true",
+	}
+
+	convs, err := h.Repo.GetConversations(c, userID, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch conversations"})
 		return
 	}
+	h.Hub.DescribeConversations(c, userID, convs)
 
 	c.JSON(http.StatusOK, convs)
 }
@@ -114,6 +141,11 @@ func (h *ChatHandler) CreateGroupChat(c *gin.Context) {
 		return
 	}
 
+	if err := h.Hub.CheckBlocked(c, userID, req.UserIDs...); err != nil {
+		writeBlockError(c, err)
+		return
+	}
+
 	if len(req.UserIDs) == 1 {
 		targetUserID := req.UserIDs[0]
 		existingConv, err := h.Repo.FindDirectConversation(c, userID, targetUserID)
@@ -134,14 +166,27 @@ func (h *ChatHandler) CreateGroupChat(c *gin.Context) {
         groupName = "New Group"
     }
 
-	conv := &domain.Conversation{
-		ID:        uuid.New(),
-		Name:      groupName,
-		IsGroup:   len(req.UserIDs) > 1, // Logic: >1 target user means group
-		CreatedAt: time.Now(),
+	creatorID, err := uuid.Parse(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
 	}
 
-	if err := h.Repo.CreateConversation(c, conv, allUserIDs); err != nil {
+	var conv *domain.Conversation
+	if len(req.UserIDs) == 1 {
+		// A new DM may have to wait in the recipient's message requests.
+		conv, err = h.Hub.OpenDirectConversation(c, userID, req.UserIDs[0])
+	} else {
+		conv = &domain.Conversation{
+			ID:        uuid.New(),
+			Name:      groupName,
+			IsGroup:   true,
+			CreatedBy: &creatorID,
+			CreatedAt: time.Now(),
+		}
+		err = h.Repo.CreateConversation(c, conv, allUserIDs)
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create group"})
 		return
 	}
@@ -155,9 +200,7 @@ func (h *ChatHandler) CreateGroupChat(c *gin.Context) {
 		"created_at":      conv.CreatedAt,
 	}
 
-	if msgBytes, err := json.Marshal(wsMsg); err == nil {
-		h.Hub.Broadcast(msgBytes)
-	}
+	h.Hub.PublishToUsers(c, allUserIDs, wsMsg)
 
 	c.JSON(http.StatusCreated, gin.H{
 		"conversation_id": conv.ID.String(),
@@ -168,172 +211,242 @@ func (h *ChatHandler) CreateGroupChat(c *gin.Context) {
 
 func (h *ChatHandler) GetMessageHistory(c *gin.Context) {
 	conversationID := c.Param("id")
-	
-	limitStr := c.DefaultQuery("limit", "50")
-	offsetStr := c.DefaultQuery("offset", "0")
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
-	msgs, err := h.Repo.GetMessageHistory(c, conversationID, limit, offset)
+	participant, err := h.Repo.GetParticipant(c, conversationID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not a participant of this conversation"})
 		return
 	}
 
-	c.JSON(http.StatusOK, msgs)
-}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 
-func (h *ChatHandler) SearchMessages(c *gin.Context) {
-	conversationID := c.Query("conversation_id")
-	query := c.Query("q")
+	query, err := h.Repo.NewMessagePageQuery(c, conversationID, c.Query("before"), c.Query("after"), limit)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
+		return
+	}
+	query.VisibleFrom = participant.HiddenAt
 
-	if conversationID == "" || query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "conversation_id and q parameters are required"})
+	query.HiddenSenderIDs, err = h.Hub.HiddenSenderIDs(c, conversationID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
 		return
 	}
 
-	msgs, err := h.Repo.SearchMessages(c, conversationID, query)
+	msgs, nextCursor, err := h.Repo.GetMessagePage(c, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Search failed"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
 		return
 	}
+	h.Media.SignMessages(c, msgs)
 
-	c.JSON(http.StatusOK, msgs)
+	c.JSON(http.StatusOK, gin.H{
+		"messages":    msgs,
+		"next_cursor": nextCursor,
+	})
 }
 
-func (h *ChatHandler) AddParticipant(c *gin.Context) {
+func (h *ChatHandler) MarkAsRead(c *gin.Context) {
 	conversationID := c.Param("id")
-	var req ParticipantRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
-	if err := h.Repo.AddParticipant(c, conversationID, req.UserID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add participant"})
+	var req MarkReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	wsMsg := map[string]interface{}{
-		"type":            "participant_added",
-		"conversation_id": conversationID,
-		"user_id":         req.UserID,
-	}
-	if msgBytes, err := json.Marshal(wsMsg); err == nil {
-		h.Hub.Broadcast(msgBytes)
+	messageID, err := h.Hub.MarkRead(c, conversationID, userID, req.MessageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNotParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Message not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark conversation as read"})
+		}
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Participant added"})
+	c.JSON(http.StatusOK, gin.H{"message": "Conversation marked as read", "last_read_message_id": messageID})
 }
 
-func (h *ChatHandler) RemoveParticipant(c *gin.Context) {
-	conversationID := c.Param("id")
-	var req ParticipantRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+// SearchMessages searches every conversation the caller is in, or only
+// conversation_id when given. Pass next_cursor back as cursor for more.
+func (h *ChatHandler) SearchMessages(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
-	if err := h.Repo.RemoveParticipant(c, conversationID, req.UserID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove participant"})
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q parameter is required"})
 		return
 	}
 
-	wsMsg := map[string]interface{}{
-		"type":            "participant_removed",
-		"conversation_id": conversationID,
-		"user_id":         req.UserID,
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	query := repositories.SearchQuery{
+		UserID:          userID,
+		Text:            text,
+		ConversationID:  c.Query("conversation_id"),
+		HiddenSenderIDs: h.Hub.BlockedUserIDs(c, userID),
+		Limit:           limit,
+	}
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := repositories.DecodeSearchCursor(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		query.Cursor = &cursor
 	}
-	if msgBytes, err := json.Marshal(wsMsg); err == nil {
-		h.Hub.Broadcast(msgBytes)
+
+	hits, nextCursor, err := h.Repo.SearchMessages(c, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Search failed"})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Participant removed"})
+	c.JSON(http.StatusOK, gin.H{
+		"results":     hits,
+		"next_cursor": nextCursor,
+	})
 }
 
-func (h *ChatHandler) UploadMedia(c *gin.Context){
-	file, header, err := c.Request.FormFile("file")
-
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file provided"})
+func (h *ChatHandler) GetPresence(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
-	defer file.Close()
+	requested := strings.Split(c.Query("user_ids"), ",")
 
-	accessKey := os.Getenv("MINIO_ACCESS_KEY_ID")   
-    secretKey := os.Getenv("MINIO_SECRET_ACCESS_KEY")
-	bucketName := os.Getenv("MINIO_BUCKET_NAME")
+	// Presence is only visible to people who share a conversation.
+	contacts, err := h.Repo.GetContactIDs(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch presence"})
+		return
+	}
+	visible := map[string]bool{userID: true}
+	for _, id := range contacts {
+		visible[id] = true
+	}
 
-	endpoint := os.Getenv("MINIO_ENDPOINT") 
-    if endpoint == "" {
-        endpoint = "localhost:9000" 
-    }
+	var userIDs []string
+	for _, id := range requested {
+		id = strings.TrimSpace(id)
+		if id != "" && visible[id] {
+			userIDs = append(userIDs, id)
+		}
+	}
 
-	publicEndpoint := os.Getenv("MINIO_PUBLIC_ENDPOINT")
-    if publicEndpoint == "" {
-        publicEndpoint = "http://localhost:9000"
-    }
+	presences, err := h.Hub.GetPresence(c, userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch presence"})
+		return
+	}
 
-	minioClient, err := minio.New(endpoint, &minio.Options{
-        Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-        Secure: os.Getenv("MINIO_USE_SSL") == "true",
-    })
+	c.JSON(http.StatusOK, presences)
+}
 
+// UploadMedia stores an attachment in the private media bucket. The form
+// field "kind" may say what the file is meant to be (e.g. "audio" for a voice
+// note) and "duration_ms" gives the length of audio and video. The returned
+// attachment_id is what a chat message refers to; media_url is a short-lived
+// preview link for the uploader.
+func (h *ChatHandler) UploadMedia(c *gin.Context){
+	userID := c.GetHeader("X-User-ID")
+	uploaderID, err := uuid.Parse(userID)
 	if err != nil {
-		fmt.Printf("MinIO Connection Error: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to connect to storage"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
-    }
+	}
 
-    objectName := uuid.New().String() + filepath.Ext(header.Filename)
-    contentType := header.Header.Get("Content-Type")
+	if h.Media == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Media storage is not configured"})
+		return
+	}
 
-	ctx := context.Background()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, media.MaxUploadSize)
 
-	exists, err := minioClient.BucketExists(ctx, bucketName)
-    if err != nil {
-         fmt.Printf("Bucket Check Error: %v\n", err)
-         c.JSON(http.StatusInternalServerError, gin.H{"error": "Storage bucket check failed"})
-         return
-    }
-    if !exists {
-        err = minioClient.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
-        if err != nil {
-             c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create storage bucket"})
-             return
-        }
-    }
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file provided"})
+		return
+	}
+	defer file.Close()
 
-    _, err = minioClient.PutObject(ctx, bucketName, objectName, file, header.Size, minio.PutObjectOptions{
-        ContentType: contentType,
-    })
+	durationMs, _ := strconv.ParseInt(c.PostForm("duration_ms"), 10, 64)
 
-    if err != nil {
-        fmt.Printf("Upload Error: %v\n", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload to storage"})
-        return
-    }
+	attachment, err := h.Media.Save(c, media.Upload{
+		UploaderID:   uploaderID,
+		File:         file,
+		FileName:     header.Filename,
+		Size:         header.Size,
+		DeclaredKind: c.PostForm("kind"),
+		DurationMs:   durationMs,
+	})
+	if err != nil {
+		var tooLarge *media.TooLargeError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case errors.Is(err, media.ErrEmptyFile),
+			errors.Is(err, media.ErrUnsupportedType),
+			errors.Is(err, media.ErrInvalidDuration):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Failed to upload media for user %s: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload to storage"})
+		}
+		return
+	}
 
-    fileURL := fmt.Sprintf("%s/%s/%s", publicEndpoint, bucketName, objectName)
-    
-    c.JSON(http.StatusOK, gin.H{
-        "media_url": fileURL,
-        "type":      getHeaderType(contentType),
-    })
+	url, err := h.Media.URL(c, attachment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign media URL"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"attachment_id": attachment.ID,
+		"media_url":     url,
+		"type":          attachment.Kind,
+		"attachment":    attachment,
+	})
 }
 
+// DeleteConversation removes the conversation from the caller's list only.
+// Everyone else keeps it, and the caller sees it again, without the old
+// history, once a new message arrives. Use LeaveGroup to stop being a member.
 func (h *ChatHandler) DeleteConversation(c *gin.Context) {
 	conversationID := c.Param("id")
 	userID := c.GetHeader("X-User-ID")
-
-	if err := h.Repo.RemoveParticipant(c, conversationID, userID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete conversation"})
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
-	if err := h.Repo.DeleteConversation(c, conversationID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete conversation"})
+	if err := h.Repo.HideConversation(c, conversationID, userID); err != nil {
+		writeGroupError(c, err, "Failed to delete conversation")
 		return
 	}
 
@@ -342,19 +455,11 @@ func (h *ChatHandler) DeleteConversation(c *gin.Context) {
 		"conversation_id": conversationID,
 		"deleted_by":      userID,
 	}
-
-	if msgBytes, err := json.Marshal(wsMsg); err == nil {
-		h.Hub.Broadcast(msgBytes)
-	}
+	h.Hub.PublishToUsers(c, []string{userID}, wsMsg)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Conversation deleted"})
 }
 
-func getHeaderType(contentType string) string {
-    if contentType == "video/mp4" { return "video" }
-    return "image"
-}
-
 func (h *ChatHandler) ShareContent(c *gin.Context) {
 	var req ShareContentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -368,26 +473,37 @@ func (h *ChatHandler) ShareContent(c *gin.Context) {
 		return
 	}
 
-	var conversationID uuid.UUID
-	
-	existingConv, err := h.Repo.FindDirectConversation(c, senderID, req.RecipientID)
-	if err == nil && existingConv != nil {
-		conversationID = existingConv.ID
-	} else {
-		conv := &domain.Conversation{
-			Name:      "Direct Message",
-			IsGroup:   false,
-			CreatedAt: time.Now(),
-		}
-		userIDs := []string{senderID, req.RecipientID}
-		if err := h.Repo.CreateConversation(c, conv, userIDs); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create conversation for sharing"})
+	sID, err := uuid.Parse(senderID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	if _, err := uuid.Parse(req.RecipientID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipient_id"})
+		return
+	}
+	switch req.Type {
+	case "post", "story", "reel":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be post, story or reel"})
+		return
+	}
+
+	conv, err := h.Hub.OpenDirectConversation(c, senderID, req.RecipientID)
+	if err != nil {
+		if errors.Is(err, ws.ErrBlocked) || errors.Is(err, ws.ErrBlockCheckUnavailable) {
+			writeBlockError(c, err)
 			return
 		}
-		conversationID = conv.ID
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create conversation for sharing"})
+		return
 	}
+	conversationID := conv.ID
 
-	sID, _ := uuid.Parse(senderID)
+	if err := h.Hub.CheckSendRate(c, conversationID.String(), senderID, req.ContentID); err != nil {
+		writeSendRateError(c, err)
+		return
+	}
 	
 	msg := &domain.Message{
 		ID:             uuid.New(),
@@ -404,22 +520,33 @@ func (h *ChatHandler) ShareContent(c *gin.Context) {
 		return
 	}
 
-	wsMsg := map[string]interface{}{
-		"type":            "new_message",
-		"id":              msg.ID,
-		"conversation_id": msg.ConversationID,
-		"sender_id":       msg.SenderID,
-		"content":         msg.Content,
-		"media_url":       msg.MediaURL,
-		"media_type":      msg.MediaType,
-		"created_at":      msg.CreatedAt,
-	}
+	h.Hub.PublishMessage(c, msg)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Content shared successfully", "conversation_id": conversationID})
+}
 
-	if msgBytes, err := json.Marshal(wsMsg); err == nil {
-		h.Hub.Broadcast(msgBytes)
+// writeSendRateError answers a send rejected by the rate limiter or the
+// spam checks.
+// writeBlockError answers a failed CheckBlocked.
+func writeBlockError(c *gin.Context, err error) {
+	if errors.Is(err, ws.ErrBlockCheckUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
 	}
+	c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Content shared successfully", "conversation_id": conversationID})
+func writeSendRateError(c *gin.Context, err error) {
+	var rateLimited *ws.RateLimitError
+	switch {
+	case errors.As(err, &rateLimited):
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	case errors.Is(err, ws.ErrSpam):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send message"})
+	}
 }
 
 func (h *ChatHandler) GetCallToken(c *gin.Context) {
@@ -447,41 +574,19 @@ func (h *ChatHandler) GenerateCallToken(c *gin.Context) {
 		return
 	}
 
-	appID := os.Getenv("AGORA_APP_ID")
-	appCertificate := os.Getenv("AGORA_APP_CERTIFICATE")
-
-	if appID == "" || appCertificate == "" {
-		fmt.Println("Error: AGORA_APP_ID or AGORA_APP_CERTIFICATE not set")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Voice service configuration error"})
-		return
-	}
-
-	channelName := conversationID
-	
-	tokenUID := uint32(0) 
-	
-	tokenExpirationInSeconds := uint32(86400) 
-	privilegeExpirationInSeconds := uint32(86400)
-
-	token, err := rtctokenbuilder2.BuildTokenWithUid(
-		appID,
-		appCertificate,
-		channelName,
-		tokenUID,
-		rtctokenbuilder2.RolePublisher,
-		tokenExpirationInSeconds,
-		privilegeExpirationInSeconds,
-	)
-
+	creds, err := h.Hub.CallToken(c, conversationID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate token: %v", err)})
+		switch {
+		case errors.Is(err, ws.ErrBlocked), errors.Is(err, ws.ErrBlockCheckUnavailable):
+			writeBlockError(c, err)
+		case errors.Is(err, calls.ErrNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Voice service configuration error"})
+		default:
+			writeGroupError(c, err, "Failed to generate token")
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token":        token,
-		"app_id":       appID,
-		"channel_name": channelName,
-	})
+	c.JSON(http.StatusOK, creds)
 }
 
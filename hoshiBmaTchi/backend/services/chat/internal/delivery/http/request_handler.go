@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AcceptRequest moves a message request into the caller's inbox. The sender
+// is told so their client can show the conversation as accepted.
+func (h *ChatHandler) AcceptRequest(c *gin.Context) {
+	conversationID := c.Param("id")
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.Repo.AcceptMessageRequest(c, conversationID, userID); err != nil {
+		writeGroupError(c, err, "Failed to accept message request")
+		return
+	}
+
+	wsMsg := map[string]interface{}{
+		"type":            "request_accepted",
+		"conversation_id": conversationID,
+		"user_id":         userID,
+	}
+	h.Hub.PublishToConversation(c, conversationID, wsMsg)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Message request accepted"})
+}
+
+// DeclineRequest deletes a message request. The sender is not notified; the
+// conversation just disappears from the caller's devices.
+func (h *ChatHandler) DeclineRequest(c *gin.Context) {
+	conversationID := c.Param("id")
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	objectKeys, err := h.Repo.DeclineMessageRequest(c, conversationID, userID)
+	if err != nil {
+		writeGroupError(c, err, "Failed to decline message request")
+		return
+	}
+	h.Hub.RemoveObjects(c, objectKeys)
+
+	h.Hub.InvalidateParticipants(conversationID)
+
+	wsMsg := map[string]interface{}{
+		"type":            "conversation_deleted",
+		"conversation_id": conversationID,
+		"deleted_by":      userID,
+	}
+	h.Hub.PublishToUsers(c, []string{userID}, wsMsg)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Message request declined"})
+}
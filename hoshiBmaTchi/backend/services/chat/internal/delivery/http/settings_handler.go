@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// maxMuteMinutes caps a timed mute at a year; longer mutes use -1.
+const maxMuteMinutes = 365 * 24 * 60
+
+// ConversationSettingsRequest updates the caller's inbox settings. Omitted
+// fields stay as they are. MuteMinutes of 0 unmutes and -1 mutes until the
+// user turns it back on.
+type ConversationSettingsRequest struct {
+	MuteMinutes *int  `json:"mute_minutes"`
+	Pinned      *bool `json:"pinned"`
+	Archived    *bool `json:"archived"`
+}
+
+func (h *ChatHandler) UpdateConversationSettings(c *gin.Context) {
+	conversationID := c.Param("id")
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req ConversationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings := repositories.ParticipantSettings{Pinned: req.Pinned, Archived: req.Archived}
+	if req.MuteMinutes != nil {
+		var until time.Time
+		switch {
+		case *req.MuteMinutes == -1:
+			until = domain.MutedForever
+		case *req.MuteMinutes > 0 && *req.MuteMinutes <= maxMuteMinutes:
+			until = time.Now().Add(time.Duration(*req.MuteMinutes) * time.Minute)
+		case *req.MuteMinutes < 0 || *req.MuteMinutes > maxMuteMinutes:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mute_minutes must be -1, 0 or at most a year"})
+			return
+		}
+		settings.MutedUntil = &until
+	}
+
+	participant, err := h.Repo.UpdateParticipantSettings(c, conversationID, userID, settings)
+	if err != nil {
+		writeGroupError(c, err, "Failed to update conversation settings")
+		return
+	}
+
+	resp := gin.H{
+		"conversation_id": conversationID,
+		"is_pinned":       participant.IsPinned,
+		"is_archived":     participant.IsArchived,
+		"muted_until":     participant.MutedUntil,
+	}
+
+	// The user's other devices move the conversation in their lists too.
+	frame := gin.H{"type": "conversation_settings_updated"}
+	for k, v := range resp {
+		frame[k] = v
+	}
+	h.Hub.PublishToUsers(c, []string{userID}, frame)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+type DisappearingRequest struct {
+	Mode string `json:"mode" binding:"required"`
+}
+
+// SetDisappearingMessages turns the conversation's disappearing-messages
+// timer on, off or to another duration. Messages already sent keep the
+// timer they were sent with.
+func (h *ChatHandler) SetDisappearingMessages(c *gin.Context) {
+	conversationID := c.Param("id")
+	actorID := c.GetHeader("X-User-ID")
+	if actorID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req DisappearingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	after, ok := domain.DisappearingModes[req.Mode]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode must be one of off, 24h, 7d or 90d"})
+		return
+	}
+
+	if err := h.Repo.SetDisappearing(c, conversationID, actorID, after); err != nil {
+		writeGroupError(c, err, "Failed to update disappearing messages")
+		return
+	}
+
+	frame := map[string]interface{}{
+		"type":            "disappearing_changed",
+		"mode":            req.Mode,
+		"disappear_after": int64(after / time.Second),
+		"updated_by":      actorID,
+	}
+	h.announce(c, conversationID, frame,
+		domain.SystemEvent{Event: "disappearing_changed", ActorID: actorID, Value: req.Mode})
+
+	c.JSON(http.StatusOK, gin.H{"mode": req.Mode, "disappear_after": int64(after / time.Second)})
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sync returns the caller's events after the sequence in ?since=, across all
+// their conversations. It answers the same as a WebSocket resume frame.
+func (h *ChatHandler) Sync(c *gin.Context) {
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	since, err := strconv.ParseInt(c.DefaultQuery("since", "0"), 10, 64)
+	if err != nil || since < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since must be a non-negative sequence number"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	batch, err := h.Hub.Sync(c, userID, since, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
@@ -0,0 +1,235 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"html/template"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+)
+
+// The archive holds an index page and, for every conversation, a JSON and
+// an HTML transcript next to its attachment files:
+//
+//	index.html
+//	conversations.json
+//	conversations/<id>/messages.json
+//	conversations/<id>/transcript.html
+//	conversations/<id>/attachments/<attachment id><ext>
+
+type Person struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// DisplayName falls back to the ID for users whose profile is unavailable.
+func (p Person) DisplayName() string {
+	if p.Username != "" {
+		return p.Username
+	}
+	return p.ID
+}
+
+type Conversation struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	IsGroup      bool      `json:"is_group"`
+	CreatedAt    time.Time `json:"created_at"`
+	Participants []Person  `json:"participants"`
+	MessageCount int       `json:"message_count"`
+	Path         string    `json:"path"`
+}
+
+type Attachment struct {
+	FileName string `json:"file_name,omitempty"`
+	MimeType string `json:"mime_type"`
+	Kind     string `json:"kind"`
+	Size     int64  `json:"size"`
+
+	// Path is where the file sits in the archive; empty if it could not be
+	// included.
+	Path string `json:"path,omitempty"`
+}
+
+type Reaction struct {
+	UserID string `json:"user_id"`
+	Emoji  string `json:"emoji"`
+}
+
+type Message struct {
+	ID         string      `json:"id"`
+	Sender     Person      `json:"sender"`
+	CreatedAt  time.Time   `json:"created_at"`
+	EditedAt   *time.Time  `json:"edited_at,omitempty"`
+	MediaType  string      `json:"media_type"`
+	Content    string      `json:"content,omitempty"`
+	Text       string      `json:"text"`
+	ReplyToID  string      `json:"reply_to_id,omitempty"`
+	IsUnsent   bool        `json:"is_unsent,omitempty"`
+	Attachment *Attachment `json:"attachment,omitempty"`
+	Reactions  []Reaction  `json:"reactions,omitempty"`
+}
+
+func conversationDir(conversationID string) string {
+	return path.Join("conversations", conversationID)
+}
+
+// attachmentPath keeps the original extension so the file opens with the
+// right program, but not the original name, which the uploader chose.
+func attachmentPath(conversationID string, a *domain.Attachment) string {
+	ext := strings.ToLower(filepath.Ext(a.FileName))
+	return path.Join(conversationDir(conversationID), "attachments", a.ID.String()+ext)
+}
+
+// newMessage turns a stored message into its export form. Unsent messages
+// keep their place in the transcript without their content.
+func newMessage(m domain.Message, sender Person) Message {
+	out := Message{
+		ID:        m.ID.String(),
+		Sender:    sender,
+		CreatedAt: m.CreatedAt,
+		EditedAt:  m.EditedAt,
+		MediaType: m.MediaType,
+		IsUnsent:  m.IsUnsent,
+	}
+	if m.ReplyToID != nil {
+		out.ReplyToID = m.ReplyToID.String()
+	}
+	for _, r := range m.Reactions {
+		out.Reactions = append(out.Reactions, Reaction{UserID: r.UserID.String(), Emoji: r.Emoji})
+	}
+	if m.IsUnsent {
+		out.Text = "This message was unsent"
+		return out
+	}
+
+	out.Content = m.Content
+	out.Text = m.Content
+	if m.MediaType == domain.MediaTypeSystem || m.MediaType == domain.MediaTypeCall || m.IsShare() {
+		out.Text = m.Preview()
+	}
+	if a := m.Attachment; a != nil {
+		out.Attachment = &Attachment{FileName: a.FileName, MimeType: a.MimeType, Kind: a.Kind, Size: a.Size}
+	}
+	return out
+}
+
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeFile(zw *zip.Writer, name string, r io.Reader) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func writeTranscript(zw *zip.Writer, conv Conversation, messages []Message) error {
+	w, err := zw.Create(path.Join(conv.Path, "transcript.html"))
+	if err != nil {
+		return err
+	}
+	return transcriptTemplate.Execute(w, struct {
+		Conversation Conversation
+		Messages     []Message
+	}{conv, messages})
+}
+
+func writeIndex(zw *zip.Writer, owner Person, exportedAt time.Time, convs []Conversation) error {
+	if err := writeJSON(zw, "conversations.json", convs); err != nil {
+		return err
+	}
+	w, err := zw.Create("index.html")
+	if err != nil {
+		return err
+	}
+	return indexTemplate.Execute(w, struct {
+		Owner         Person
+		ExportedAt    time.Time
+		Conversations []Conversation
+	}{owner, exportedAt, convs})
+}
+
+var templateFuncs = template.FuncMap{
+	"datetime": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"relative": func(from, to string) string {
+		rel, err := filepath.Rel(from, to)
+		if err != nil {
+			return to
+		}
+		return filepath.ToSlash(rel)
+	},
+}
+
+const pageStyle = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 760px; margin: 2em auto; color: #262626; }
+h1 { font-size: 1.4em; }
+.meta { color: #8e8e8e; font-size: 0.85em; }
+.message { padding: 0.6em 0; border-bottom: 1px solid #efefef; }
+.sender { font-weight: 600; }
+.system { color: #8e8e8e; font-style: italic; }
+.content { white-space: pre-wrap; margin-top: 0.2em; }
+img, video { max-width: 320px; display: block; margin-top: 0.4em; }
+`
+
+var indexTemplate = template.Must(template.New("index").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Messages of {{.Owner.DisplayName}}</title>
+<style>` + pageStyle + `</style>
+</head>
+<body>
+<h1>Messages of {{.Owner.DisplayName}}</h1>
+<p class="meta">Exported {{datetime .ExportedAt}}</p>
+<ul>
+{{range .Conversations}}<li><a href="{{.Path}}/transcript.html">{{.Title}}</a> <span class="meta">{{.MessageCount}} messages</span></li>
+{{else}}<li>No conversations.</li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+var transcriptTemplate = template.Must(template.New("transcript").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Conversation.Title}}</title>
+<style>` + pageStyle + `</style>
+</head>
+<body>
+<p><a href="../../index.html">All conversations</a></p>
+<h1>{{.Conversation.Title}}</h1>
+<p class="meta">{{range $i, $p := .Conversation.Participants}}{{if $i}}, {{end}}{{$p.DisplayName}}{{end}}</p>
+{{$dir := .Conversation.Path}}
+{{range .Messages}}<div class="message">
+{{if or (eq .MediaType "system") (eq .MediaType "call")}}<div class="system">{{.Text}} <span class="meta">{{datetime .CreatedAt}}</span></div>
+{{else}}<div><span class="sender">{{.Sender.DisplayName}}</span> <span class="meta">{{datetime .CreatedAt}}{{if .EditedAt}} · edited{{end}}</span></div>
+{{if .Text}}<div class="content{{if .IsUnsent}} system{{end}}">{{.Text}}</div>
+{{end}}{{with .Attachment}}{{if .Path}}{{$src := relative $dir .Path}}{{if eq .Kind "image"}}<img src="{{$src}}" alt="{{.FileName}}">
+{{else if eq .Kind "video"}}<video src="{{$src}}" controls></video>
+{{else if eq .Kind "audio"}}<audio src="{{$src}}" controls></audio>
+{{else}}<a href="{{$src}}">{{if .FileName}}{{.FileName}}{{else}}Attachment{{end}}</a>
+{{end}}{{else}}<div class="system">Attachment unavailable</div>
+{{end}}{{end}}{{if .Reactions}}<div class="meta">{{range .Reactions}}{{.Emoji}} {{end}}</div>
+{{end}}{{end}}</div>
+{{else}}<p class="system">No messages.</p>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,396 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/clients"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/media"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+)
+
+const (
+	// ArchiveTTL is how long a finished archive can be downloaded before it
+	// is deleted. Presigned links cannot outlive seven days anyway.
+	ArchiveTTL = 72 * time.Hour
+
+	pollInterval = 30 * time.Second
+
+	// stuckAfter hands a job to another worker when the instance running it
+	// died halfway.
+	stuckAfter = time.Hour
+
+	messageBatch = 500
+)
+
+var ErrNotConfigured = errors.New("exports are not configured")
+
+// Service builds conversation archives in the background. Jobs live in the
+// database, so any instance can pick them up and none are lost on restart.
+type Service struct {
+	repo     *repositories.ChatRepository
+	media    *media.Store
+	archives *clients.MinioClient
+	users    *clients.UserServiceClient
+	events   *events.Publisher
+	wake     chan struct{}
+}
+
+// NewService builds the export service. archives is the private bucket
+// finished exports go to; without it exports are disabled. Without media
+// storage archives carry no attachment files, and without a publisher no
+// email goes out.
+func NewService(repo *repositories.ChatRepository, store *media.Store, archives *clients.MinioClient, users *clients.UserServiceClient, publisher *events.Publisher) *Service {
+	return &Service{
+		repo:     repo,
+		media:    store,
+		archives: archives,
+		users:    users,
+		events:   publisher,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Request queues an export of everything the user can see in chat.
+func (s *Service) Request(ctx context.Context, userID string) (*domain.ExportJob, error) {
+	if s.archives == nil {
+		return nil, ErrNotConfigured
+	}
+	job, err := s.repo.CreateExportJob(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Job returns one of the user's exports with a download link once it is
+// ready.
+func (s *Service) Job(ctx context.Context, jobID, userID string) (*domain.ExportJob, string, error) {
+	job, err := s.repo.GetExportJob(ctx, jobID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	url, err := s.DownloadURL(ctx, job)
+	if err != nil {
+		log.Printf("Failed to sign export %s: %v", job.ID, err)
+	}
+	return job, url, nil
+}
+
+func (s *Service) Jobs(ctx context.Context, userID string) ([]domain.ExportJob, error) {
+	return s.repo.ListExportJobs(ctx, userID, 10)
+}
+
+// DownloadURL links to a finished archive until it expires. It returns ""
+// for jobs with nothing to download.
+func (s *Service) DownloadURL(ctx context.Context, job *domain.ExportJob) (string, error) {
+	if s.archives == nil || job.Status != domain.ExportCompleted || job.ObjectKey == "" || job.ExpiresAt == nil {
+		return "", nil
+	}
+	ttl := time.Until(*job.ExpiresAt)
+	if ttl <= 0 {
+		return "", nil
+	}
+	return s.archives.PresignedURL(ctx, job.ObjectKey, downloadName(job), ttl)
+}
+
+func downloadName(job *domain.ExportJob) string {
+	return fmt.Sprintf("messages-%s.zip", job.CreatedAt.UTC().Format("2006-01-02"))
+}
+
+// Run works through queued jobs until ctx is cancelled and deletes archives
+// past their expiry.
+func (s *Service) Run(ctx context.Context) {
+	if s.archives == nil {
+		return
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.drain(ctx)
+		s.purgeExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *Service) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := s.repo.ClaimExportJob(ctx, time.Now(), stuckAfter)
+		if err != nil {
+			log.Printf("Failed to claim export job: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		s.process(ctx, job)
+	}
+}
+
+func (s *Service) process(ctx context.Context, job *domain.ExportJob) {
+	objectKey, size, err := s.build(ctx, job)
+
+	now := time.Now()
+	job.CompletedAt = &now
+	if err != nil {
+		log.Printf("Export %s failed: %v", job.ID, err)
+		job.Status = domain.ExportFailed
+		job.Error = "The export could not be created. Please try again later."
+	} else {
+		expiresAt := now.Add(ArchiveTTL)
+		job.Status = domain.ExportCompleted
+		job.ObjectKey = objectKey
+		job.Size = size
+		job.ExpiresAt = &expiresAt
+	}
+	if err := s.repo.SaveExportJob(ctx, job); err != nil {
+		log.Printf("Failed to save export %s: %v", job.ID, err)
+		return
+	}
+
+	if job.Status == domain.ExportCompleted {
+		s.notify(ctx, job)
+	}
+}
+
+// build writes the archive to a temporary file and uploads it. Archives can
+// be far larger than anything worth holding in memory.
+func (s *Service) build(ctx context.Context, job *domain.ExportJob) (string, int64, error) {
+	tmp, err := os.CreateTemp("", "chat-export-*.zip")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	zw := zip.NewWriter(tmp)
+	if err := s.writeArchive(ctx, zw, job.UserID.String()); err != nil {
+		return "", 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return "", 0, err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	objectKey := path.Join("exports", job.UserID.String(), job.ID.String()+".zip")
+	if err := s.archives.Upload(ctx, objectKey, tmp, size, "application/zip"); err != nil {
+		return "", 0, err
+	}
+	return objectKey, size, nil
+}
+
+func (s *Service) writeArchive(ctx context.Context, zw *zip.Writer, userID string) error {
+	convs, err := s.repo.ExportConversations(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	people := newDirectory(s.users)
+	blocked, err := s.users.BlockedUserIDs(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load block relations of user %s for export: %v", userID, err)
+		blocked = map[string]bool{}
+	}
+
+	summaries := make([]Conversation, 0, len(convs))
+	for _, ec := range convs {
+		summary, err := s.writeConversation(ctx, zw, people, userID, ec, blocked)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return writeIndex(zw, people.get(ctx, userID), time.Now(), summaries)
+}
+
+func (s *Service) writeConversation(ctx context.Context, zw *zip.Writer, people *directory, userID string, ec repositories.ExportConversation, blocked map[string]bool) (Conversation, error) {
+	conv := ec.Conversation
+	summary := Conversation{
+		ID:        conv.ID.String(),
+		Title:     conv.Name,
+		IsGroup:   conv.IsGroup,
+		CreatedAt: conv.CreatedAt,
+		Path:      conversationDir(conv.ID.String()),
+	}
+	for _, p := range conv.Participants {
+		person := people.get(ctx, p.UserID.String())
+		summary.Participants = append(summary.Participants, person)
+		if !conv.IsGroup && summary.Title == "" && person.ID != userID {
+			summary.Title = person.DisplayName()
+		}
+	}
+	if summary.Title == "" {
+		summary.Title = "Conversation"
+	}
+
+	// Like history, a group export leaves out members the user has a block
+	// with.
+	q := repositories.MessagePageQuery{
+		ConversationID: summary.ID,
+		Direction:      repositories.PageAfter,
+		Limit:          messageBatch,
+		VisibleFrom:    ec.HiddenAt,
+	}
+	if conv.IsGroup {
+		for id := range blocked {
+			q.HiddenSenderIDs = append(q.HiddenSenderIDs, id)
+		}
+	}
+
+	var messages []Message
+	for {
+		page, next, err := s.repo.GetMessagePage(ctx, q)
+		if err != nil {
+			return summary, err
+		}
+		for _, m := range page {
+			out := newMessage(m, people.get(ctx, m.SenderID.String()))
+			if out.Attachment != nil {
+				out.Attachment.Path = s.writeAttachment(ctx, zw, summary.ID, m.Attachment)
+			}
+			messages = append(messages, out)
+		}
+		if next == "" {
+			break
+		}
+		cursor, err := repositories.DecodeMessageCursor(next)
+		if err != nil {
+			return summary, err
+		}
+		q.Cursor = &cursor
+	}
+	summary.MessageCount = len(messages)
+
+	if err := writeJSON(zw, path.Join(summary.Path, "messages.json"), struct {
+		Conversation Conversation `json:"conversation"`
+		Messages     []Message    `json:"messages"`
+	}{summary, messages}); err != nil {
+		return summary, err
+	}
+	return summary, writeTranscript(zw, summary, messages)
+}
+
+// writeAttachment copies an attachment into the archive and returns its path
+// there. A file that cannot be read is left out rather than failing the
+// whole export; the transcript says it is unavailable.
+func (s *Service) writeAttachment(ctx context.Context, zw *zip.Writer, conversationID string, a *domain.Attachment) string {
+	if s.media == nil {
+		return ""
+	}
+	r, err := s.media.Open(ctx, a)
+	if err != nil {
+		log.Printf("Failed to open attachment %s for export: %v", a.ID, err)
+		return ""
+	}
+	defer r.Close()
+
+	name := attachmentPath(conversationID, a)
+	if err := writeFile(zw, name, r); err != nil {
+		log.Printf("Failed to export attachment %s: %v", a.ID, err)
+		return ""
+	}
+	return name
+}
+
+// notify emails the user a link to the finished archive.
+func (s *Service) notify(ctx context.Context, job *domain.ExportJob) {
+	if s.events == nil {
+		return
+	}
+	userID := job.UserID.String()
+	email, locale, err := s.users.GetUserEmail(ctx, userID)
+	if err != nil || email == "" {
+		log.Printf("No email address for export %s: %v", job.ID, err)
+		return
+	}
+	url, err := s.DownloadURL(ctx, job)
+	if err != nil || url == "" {
+		log.Printf("Failed to sign export %s for email: %v", job.ID, err)
+		return
+	}
+
+	task := events.EmailTask{
+		Email:    email,
+		Template: events.TemplateMessageExport,
+		Data: map[string]interface{}{
+			"link":    url,
+			"expires": job.ExpiresAt.UTC().Format("January 2, 2006 at 15:04 UTC"),
+		},
+		Locale: locale,
+	}
+	if err := s.events.SendEmail(ctx, task); err != nil {
+		log.Printf("Failed to email export %s: %v", job.ID, err)
+	}
+}
+
+func (s *Service) purgeExpired(ctx context.Context) {
+	jobs, err := s.repo.ExpiredExportJobs(ctx, time.Now(), 100)
+	if err != nil {
+		log.Printf("Failed to list expired exports: %v", err)
+		return
+	}
+	for i := range jobs {
+		job := &jobs[i]
+		if err := s.archives.Remove(ctx, job.ObjectKey); err != nil {
+			log.Printf("Failed to delete export %s: %v", job.ID, err)
+			continue
+		}
+		job.ObjectKey = ""
+		if err := s.repo.SaveExportJob(ctx, job); err != nil {
+			log.Printf("Failed to save export %s: %v", job.ID, err)
+		}
+	}
+}
+
+// directory caches the profiles of everyone in an export. Unknown users
+// show up by ID.
+type directory struct {
+	users  *clients.UserServiceClient
+	people map[string]Person
+}
+
+func newDirectory(users *clients.UserServiceClient) *directory {
+	return &directory{users: users, people: map[string]Person{}}
+}
+
+func (d *directory) get(ctx context.Context, userID string) Person {
+	if p, ok := d.people[userID]; ok {
+		return p
+	}
+	p := Person{ID: userID}
+	if profile, err := d.users.GetUserProfile(ctx, userID); err == nil {
+		p.Username, p.Name = profile.Username, profile.Name
+	}
+	d.people[userID] = p
+	return p
+}
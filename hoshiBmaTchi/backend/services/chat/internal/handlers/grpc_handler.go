@@ -2,114 +2,503 @@ package handlers
 
 import (
 	"context"
-	"hash/crc32"
-	"os"
+	"errors"
+	"log"
+	"strings"
 	"time"
+	"unicode/utf8"
 
-	"github.com/AgoraIO/Tools/DynamicKey/AgoraDynamicKey/go/src/rtctokenbuilder2"
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/chat"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/media"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/ws"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 ) 
 
 type ChatGRPCServer struct {
 	pb.UnimplementedChatServiceServer
-	repo *repositories.ChatRepository
+	repo  *repositories.ChatRepository
+	hub   *ws.Hub
+	media *media.Store
 }
 
-func NewChatGRPCServer(repo *repositories.ChatRepository) *ChatGRPCServer {
-	return &ChatGRPCServer{repo: repo}
+func NewChatGRPCServer(repo *repositories.ChatRepository, hub *ws.Hub, store *media.Store) *ChatGRPCServer {
+	return &ChatGRPCServer{repo: repo, hub: hub, media: store}
+}
+
+func toPBMessage(m domain.Message) *pb.Message {
+	pbMsg := &pb.Message{
+		Id:             m.ID.String(),
+		ConversationId: m.ConversationID.String(),
+		SenderId:       m.SenderID.String(),
+		Content:        m.Content,
+		MediaUrl:       m.MediaURL,
+		MediaType:      m.MediaType,
+		CreatedAt:      m.CreatedAt.Format(time.RFC3339),
+		IsUnsent:       m.IsUnsent,
+	}
+	if m.ReplyToID != nil {
+		pbMsg.ReplyToId = m.ReplyToID.String()
+	}
+	if m.ClientMsgID != nil {
+		pbMsg.ClientMsgId = *m.ClientMsgID
+	}
+	if m.EditedAt != nil {
+		pbMsg.EditedAt = m.EditedAt.Format(time.RFC3339)
+	}
+	for _, r := range m.Reactions {
+		pbMsg.Reactions = append(pbMsg.Reactions, &pb.Reaction{UserId: r.UserID.String(), Emoji: r.Emoji})
+	}
+	if a := m.Attachment; a != nil && !m.IsUnsent {
+		pbMsg.Attachment = &pb.Attachment{
+			Id:         a.ID.String(),
+			Kind:       a.Kind,
+			MimeType:   a.MimeType,
+			FileName:   a.FileName,
+			Size:       a.Size,
+			DurationMs: a.DurationMs,
+		}
+	}
+	return pbMsg
+}
+
+// repoError maps repository rule violations onto gRPC status codes.
+func repoError(err error, fallback string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "Message not found")
+	case errors.Is(err, repositories.ErrNotParticipant),
+		errors.Is(err, repositories.ErrNotMessageSender),
+		errors.Is(err, repositories.ErrNotAdmin),
+		errors.Is(err, repositories.ErrNotOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, repositories.ErrMessageUnsent),
+		errors.Is(err, repositories.ErrMessageNotEditable),
+		errors.Is(err, repositories.ErrEditWindowExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repositories.ErrEmptyMessage),
+		errors.Is(err, repositories.ErrInvalidReaction),
+		errors.Is(err, repositories.ErrInvalidReplyTarget),
+		errors.Is(err, repositories.ErrInvalidCursor),
+		errors.Is(err, repositories.ErrInvalidAttachment):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, fallback)
+	}
+}
+
+// blockError maps a failed CheckBlocked onto a gRPC status.
+func blockError(err error) error {
+	if errors.Is(err, ws.ErrBlockCheckUnavailable) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+func sendRateError(err error) error {
+	var rateLimited *ws.RateLimitError
+	switch {
+	case errors.As(err, &rateLimited), errors.Is(err, ws.ErrSpam):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, "Failed to send message")
+	}
+}
+
+// mediaTypeFor maps the proto message type onto the media_type strings the
+// HTTP and WebSocket paths already store, e.g. "story_share".
+func mediaTypeFor(t pb.MessageType) string {
+	return strings.ToLower(t.String())
 }
 
 func (s *ChatGRPCServer) CreateGroupChat(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error){
+	if len(req.UserIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_ids is required")
+	}
+	for _, id := range req.UserIds {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid user_ids")
+		}
+	}
+
+	creatorID := req.CreatorId
+	if creatorID == "" {
+		creatorID = req.UserIds[0]
+	}
+	creator, err := uuid.Parse(creatorID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid creator_id")
+	}
+
+	if err := s.hub.CheckBlocked(ctx, creatorID, req.UserIds...); err != nil {
+		return nil, blockError(err)
+	}
+
+	// A fresh slice, so the creator is not written into the request's array.
+	userIDs := make([]string, 0, len(req.UserIds)+1)
+	userIDs = append(userIDs, req.UserIds...)
+	userIDs = append(userIDs, creatorID)
+
 	conv := &domain.Conversation{
+		ID:        uuid.New(),
 		Name:      req.Name,
 		IsGroup:   true,
+		CreatedBy: &creator,
 		CreatedAt: time.Now(),
 	}
 
-
-	err := s.repo.CreateConversation(ctx, conv, req.UserIds)
+	err = s.repo.CreateConversation(ctx, conv, userIDs)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to create group")
 	}
 
+	frame := map[string]interface{}{
+		"type":            "group_created",
+		"conversation_id": conv.ID.String(),
+		"name":            conv.Name,
+		"participants":    userIDs,
+		"created_by":      creatorID,
+		"created_at":      conv.CreatedAt,
+	}
+	s.hub.PublishToUsers(ctx, userIDs, frame)
+
 	return &pb.CreateGroupResponse{ConversationId: conv.ID.String()}, nil
 }
 
 func (s *ChatGRPCServer) GetConversations(ctx context.Context, req *pb.GetConversationsRequest) (*pb.GetConversationsResponse, error) {
-	convs, err := s.repo.GetConversations(ctx, req.UserId)
+	convs, err := s.repo.GetConversations(ctx, req.UserId, repositories.ConversationFilter{
+		Requests: req.Requests,
+		Archived: req.Archived,
+	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to fetch conversations")
 	}
+	s.hub.DescribeConversations(ctx, req.UserId, convs)
 
 	var pbConvs []*pb.Conversation
 	for _, c := range convs {
-		pbConvs = append(pbConvs, &pb.Conversation{
-			Id:        c.ID.String(),
-			Name:      c.Name,
-			IsGroup:   c.IsGroup,
-		})
+		pbConv := &pb.Conversation{
+			Id:               c.ID.String(),
+			Name:             c.Name,
+			IsGroup:          c.IsGroup,
+			LastMessage:      c.LastMessage,
+			UnreadCount:      c.UnreadCount,
+			IsPinned:         c.IsPinned,
+			IsArchived:       c.IsArchived,
+			DisappearAfter:   c.DisappearAfter,
+			DisplayName:      c.DisplayName,
+			DisplayAvatarUrl: c.DisplayAvatarURL,
+		}
+		if c.LastMessageAt != nil {
+			pbConv.LastMessageAt = c.LastMessageAt.Format(time.RFC3339)
+		}
+		if c.MutedUntil != nil {
+			pbConv.MutedUntil = c.MutedUntil.Format(time.RFC3339)
+		}
+		pbConvs = append(pbConvs, pbConv)
 	}
 
 	return &pb.GetConversationsResponse{Conversations: pbConvs}, nil
 }
 
+// historyPage loads one page of a conversation for userID, with the same
+// rules as the HTTP history endpoint: only participants may read it, and it
+// leaves out what they deleted on their side and senders they have a block
+// with.
+func (s *ChatGRPCServer) historyPage(ctx context.Context, conversationID, userID, before, after string, limit, offset int) ([]domain.Message, string, error) {
+	participant, err := s.repo.GetParticipant(ctx, conversationID, userID)
+	if err != nil {
+		return nil, "", repoError(err, "Failed to fetch history")
+	}
+
+	query, err := s.repo.NewMessagePageQuery(ctx, conversationID, before, after, limit)
+	if err != nil {
+		return nil, "", repoError(err, "Failed to fetch history")
+	}
+	if offset > 0 {
+		query.Offset = offset
+	}
+	query.VisibleFrom = participant.HiddenAt
+	query.HiddenSenderIDs, err = s.hub.HiddenSenderIDs(ctx, conversationID, userID)
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "Failed to fetch history")
+	}
+
+	msgs, nextCursor, err := s.repo.GetMessagePage(ctx, query)
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "Failed to fetch history")
+	}
+	s.media.SignMessages(ctx, msgs)
+	return msgs, nextCursor, nil
+}
+
 func (s *ChatGRPCServer) GetMessageHistory(ctx context.Context, req *pb.GetHistoryRequest) (*pb.GetHistoryResponse, error) {
-	msgs, err := s.repo.GetMessageHistory(ctx, req.ConversationId, int(req.Limit), int(req.Offset))
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	msgs, nextCursor, err := s.historyPage(ctx, req.ConversationId, req.UserId, req.Before, req.After, int(req.Limit), int(req.Offset))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "Failed to fetch history")
+		return nil, err
 	}
 
 	var pbMsgs []*pb.Message
 	for _, m := range msgs {
-		pbMsgs = append(pbMsgs, &pb.Message{
-			Id:        m.ID.String(),
-			SenderId:  m.SenderID.String(),
-			Content:   m.Content,
-			MediaUrl:  m.MediaURL,
-			CreatedAt: m.CreatedAt.Format(time.RFC3339),
-			IsUnsent:  m.IsUnsent,
-		})
+		pbMsgs = append(pbMsgs, toPBMessage(m))
 	}
 
-	return &pb.GetHistoryResponse{Messages: pbMsgs}, nil
+	return &pb.GetHistoryResponse{Messages: pbMsgs, NextCursor: nextCursor}, nil
 }
 
-func (s *ChatGRPCServer) GetCallToken(ctx context.Context, req *pb.GetCallTokenRequest) (*pb.GetCallTokenResponse, error) {
-	appID := os.Getenv("AGORA_APP_ID")
-	appCertificate := os.Getenv("AGORA_APP_CERTIFICATE")
+func (s *ChatGRPCServer) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	senderID, err := uuid.Parse(req.SenderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid sender ID")
+	}
+	if _, err := uuid.Parse(req.RecipientId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid recipient ID")
+	}
+	if req.SenderId == req.RecipientId {
+		return nil, status.Error(codes.InvalidArgument, "Cannot send a message to yourself")
+	}
+	if len(req.ClientMsgId) > 64 {
+		return nil, status.Error(codes.InvalidArgument, "client_msg_id is too long")
+	}
+
+	if req.ClientMsgId != "" {
+		existing, err := s.repo.MessageByClientMsgID(ctx, senderID, req.ClientMsgId)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to send message")
+		}
+		if existing != nil {
+			s.media.SignMessage(ctx, existing)
+			return &pb.SendMessageResponse{Message: toPBMessage(*existing), MessageId: existing.ID.String()}, nil
+		}
+	}
+
+	content := req.Content
+	switch req.MessageType {
+	case pb.MessageType_STORY_SHARE:
+		if req.StoryId == "" {
+			return nil, status.Error(codes.InvalidArgument, "story_id is required for story shares")
+		}
+		content = req.StoryId
+	case pb.MessageType_POST_SHARE:
+		if req.PostId == "" {
+			return nil, status.Error(codes.InvalidArgument, "post_id is required for post shares")
+		}
+		content = req.PostId
+	case pb.MessageType_TEXT:
+		if content == "" {
+			return nil, status.Error(codes.InvalidArgument, "Message content is empty")
+		}
+		if utf8.RuneCountInString(content) > ws.MaxContentLength {
+			return nil, status.Error(codes.InvalidArgument, "Message content is too long")
+		}
+	default:
+		if req.AttachmentId == "" && req.MediaUrl == "" {
+			return nil, status.Error(codes.InvalidArgument, "attachment_id is required for media messages")
+		}
+	}
+
+	var attachmentID *uuid.UUID
+	if req.AttachmentId != "" {
+		id, err := uuid.Parse(req.AttachmentId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid attachment_id")
+		}
+		attachmentID = &id
+	}
+
+	conv, err := s.hub.OpenDirectConversation(ctx, req.SenderId, req.RecipientId)
+	if err != nil {
+		if errors.Is(err, ws.ErrBlocked) || errors.Is(err, ws.ErrBlockCheckUnavailable) {
+			return nil, blockError(err)
+		}
+		return nil, status.Error(codes.Internal, "Failed to resolve conversation")
+	}
+
+	if err := s.hub.CheckSendRate(ctx, conv.ID.String(), req.SenderId, content); err != nil {
+		return nil, sendRateError(err)
+	}
+
+	msg := &domain.Message{
+		ID:             uuid.New(),
+		ConversationID: conv.ID,
+		SenderID:       senderID,
+		Content:        content,
+		MediaURL:       req.MediaUrl,
+		MediaType:      mediaTypeFor(req.MessageType),
+		AttachmentID:   attachmentID,
+		CreatedAt:      time.Now(),
+	}
+
+	if req.ReplyToId != "" {
+		replyToID, err := uuid.Parse(req.ReplyToId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid reply_to_id")
+		}
+		msg.ReplyToID = &replyToID
+	}
+	if req.ClientMsgId != "" {
+		msg.ClientMsgID = &req.ClientMsgId
+	}
+
+	err = s.repo.SaveMessage(ctx, msg)
+	switch {
+	case errors.Is(err, repositories.ErrDuplicateMessage):
+		s.media.SignMessage(ctx, msg)
+	case err != nil:
+		return nil, repoError(err, "Failed to send message")
+	default:
+		if err := s.hub.PublishMessage(ctx, msg); err != nil {
+			log.Printf("Failed to publish message %s: %v", msg.ID, err)
+			return nil, status.Error(codes.Unavailable, "Message was saved but could not be delivered")
+		}
+	}
+
+	return &pb.SendMessageResponse{
+		Message:   toPBMessage(*msg),
+		MessageId: msg.ID.String(),
+	}, nil
+}
+
+func (s *ChatGRPCServer) GetMessages(ctx context.Context, req *pb.GetMessagesRequest) (*pb.GetMessagesResponse, error) {
+	conv, err := s.repo.FindDirectConversation(ctx, req.UserId, req.OtherUserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &pb.GetMessagesResponse{}, nil
+		}
+		return nil, status.Error(codes.Internal, "Failed to resolve conversation")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 50
+	}
+
+	msgs, _, err := s.historyPage(ctx, conv.ID.String(), req.UserId, "", "", limit, int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	var pbMsgs []*pb.Message
+	for _, m := range msgs {
+		pbMsgs = append(pbMsgs, toPBMessage(m))
+	}
+
+	return &pb.GetMessagesResponse{Messages: pbMsgs}, nil
+}
+
+func (s *ChatGRPCServer) DeleteMessage(ctx context.Context, req *pb.DeleteMessageRequest) (*pb.DeleteMessageResponse, error) {
+	msg, err := s.repo.GetMessage(ctx, req.MessageId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "Message not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to fetch message")
+	}
+
+	if err := s.repo.UnsendMessage(ctx, req.MessageId, req.UserId); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	frame := ws.WSMessage{
+		Type:           "message_unsent",
+		ID:             req.MessageId,
+		SenderID:       req.UserId,
+		ConversationID: msg.ConversationID.String(),
+	}
+	s.hub.PublishToConversation(ctx, frame.ConversationID, frame)
+
+	return &pb.DeleteMessageResponse{Success: true}, nil
+}
 
-	if appID == "" || appCertificate == "" {
-		return nil, status.Error(codes.Internal, "Agora credentials not configured on server")
+func (s *ChatGRPCServer) MarkAsRead(ctx context.Context, req *pb.MarkAsReadRequest) (*pb.MarkAsReadResponse, error) {
+	messageID, err := s.hub.MarkRead(ctx, req.ConversationId, req.UserId, req.MessageId)
+	if err != nil {
+		return nil, repoError(err, "Failed to mark conversation as read")
 	}
 
-	uid := crc32.ChecksumIEEE([]byte(req.UserId))
+	return &pb.MarkAsReadResponse{Success: true, LastReadMessageId: messageID}, nil
+}
+
+func (s *ChatGRPCServer) AddReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.ReactionResponse, error) {
+	if _, err := s.hub.React(ctx, req.MessageId, req.UserId, req.Emoji); err != nil {
+		return nil, repoError(err, "Failed to add reaction")
+	}
+	return &pb.ReactionResponse{Success: true}, nil
+}
 
-	tokenExpireTimeInSeconds := uint32(86400)
-	privilegeExpireTimeInSeconds := uint32(86400)
+func (s *ChatGRPCServer) RemoveReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.ReactionResponse, error) {
+	if _, err := s.hub.Unreact(ctx, req.MessageId, req.UserId); err != nil {
+		return nil, repoError(err, "Failed to remove reaction")
+	}
+	return &pb.ReactionResponse{Success: true}, nil
+}
 
-	channelName := req.ConversationId
-	token, err := rtctokenbuilder2.BuildTokenWithUid(
-		appID,
-		appCertificate,
-		channelName,
-		uid,
-		rtctokenbuilder2.RolePublisher,
-		tokenExpireTimeInSeconds,
-		privilegeExpireTimeInSeconds,
-	)
+func (s *ChatGRPCServer) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error) {
+	msg, err := s.hub.EditMessage(ctx, req.MessageId, req.UserId, req.Content)
+	if err != nil {
+		return nil, repoError(err, "Failed to edit message")
+	}
+	return &pb.EditMessageResponse{Message: toPBMessage(*msg)}, nil
+}
 
+func (s *ChatGRPCServer) GetMessageEdits(ctx context.Context, req *pb.GetMessageEditsRequest) (*pb.GetMessageEditsResponse, error) {
+	msg, err := s.repo.GetMessage(ctx, req.MessageId)
+	if err != nil {
+		return nil, repoError(err, "Failed to fetch message")
+	}
+
+	isMember, err := s.repo.IsParticipant(ctx, msg.ConversationID.String(), req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to fetch message")
+	}
+	if !isMember {
+		return nil, status.Error(codes.PermissionDenied, repositories.ErrNotParticipant.Error())
+	}
+
+	edits, err := s.repo.GetEditHistory(ctx, req.MessageId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to fetch edit history")
+	}
+
+	var pbEdits []*pb.MessageEdit
+	for _, e := range edits {
+		pbEdits = append(pbEdits, &pb.MessageEdit{
+			PreviousContent: e.PreviousContent,
+			EditedAt:        e.EditedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &pb.GetMessageEditsResponse{Edits: pbEdits}, nil
+}
+
+func (s *ChatGRPCServer) GetCallToken(ctx context.Context, req *pb.GetCallTokenRequest) (*pb.GetCallTokenResponse, error) {
+	creds, err := s.hub.CallToken(ctx, req.ConversationId, req.UserId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to generate token: %v", err)
+		switch {
+		case errors.Is(err, ws.ErrBlocked), errors.Is(err, ws.ErrBlockCheckUnavailable):
+			return nil, blockError(err)
+		case errors.Is(err, calls.ErrNotConfigured):
+			return nil, status.Error(codes.Unavailable, "Agora credentials not configured on server")
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, status.Error(codes.NotFound, "Conversation not found")
+		}
+		return nil, repoError(err, "Failed to generate token")
 	}
 
 	return &pb.GetCallTokenResponse{
-		Token:       token,
-		ChannelName: channelName,
-		AppId:       appID,
+		Token:       creds.Token,
+		ChannelName: creds.ChannelName,
+		AppId:       creds.AppID,
+		Uid:         creds.UID,
+		ExpiresAt:   creds.ExpiresAt.Format(time.RFC3339),
 	}, nil
 }
 
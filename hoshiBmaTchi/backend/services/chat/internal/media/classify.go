@@ -0,0 +1,119 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+)
+
+var (
+	ErrEmptyFile       = errors.New("file is empty")
+	ErrUnsupportedType = errors.New("file type is not supported")
+	ErrInvalidDuration = errors.New("invalid duration")
+)
+
+// TooLargeError reports an upload over the size limit of its kind.
+type TooLargeError struct {
+	Kind  string
+	Limit int64
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("%s attachments are limited to %d MB", e.Kind, e.Limit>>20)
+}
+
+var sizeLimits = map[string]int64{
+	domain.AttachmentImage: 10 << 20,
+	domain.AttachmentVideo: 100 << 20,
+	domain.AttachmentAudio: 20 << 20,
+	domain.AttachmentFile:  25 << 20,
+}
+
+// MaxUploadSize is the largest body any upload may have.
+const MaxUploadSize = 100<<20 + 1<<20
+
+var maxDurations = map[string]time.Duration{
+	domain.AttachmentAudio: 15 * time.Minute,
+	domain.AttachmentVideo: 30 * time.Minute,
+}
+
+var kindsByType = map[string]string{
+	"image/jpeg":      domain.AttachmentImage,
+	"image/png":       domain.AttachmentImage,
+	"image/gif":       domain.AttachmentImage,
+	"image/webp":      domain.AttachmentImage,
+	"video/mp4":       domain.AttachmentVideo,
+	"video/webm":      domain.AttachmentVideo,
+	"audio/mpeg":      domain.AttachmentAudio,
+	"audio/wave":      domain.AttachmentAudio,
+	"audio/aiff":      domain.AttachmentAudio,
+	"audio/ogg":       domain.AttachmentAudio,
+	"application/ogg": domain.AttachmentAudio,
+}
+
+// Files are served as downloads, but only these types keep their own
+// Content-Type. Anything else, HTML in particular, is stored as a plain
+// byte stream.
+var safeFileTypes = map[string]bool{
+	"application/pdf":           true,
+	"application/zip":           true,
+	"application/x-gzip":        true,
+	"text/plain; charset=utf-8": true,
+}
+
+// Classify works out what an upload is from its first bytes. The declared
+// kind is only a hint: it cannot turn a PDF into an image, but it does tell a
+// voice note recorded into a webm or mp4 container apart from a video, which
+// sniffing alone cannot do.
+func Classify(head []byte, declaredKind string) (mimeType, kind string, err error) {
+	if len(head) == 0 {
+		return "", "", ErrEmptyFile
+	}
+
+	sniffed := http.DetectContentType(head)
+	kind, known := kindsByType[sniffed]
+
+	switch {
+	case declaredKind == domain.AttachmentAudio && kind == domain.AttachmentVideo:
+		return "audio/" + strings.TrimPrefix(sniffed, "video/"), domain.AttachmentAudio, nil
+	case declaredKind == domain.AttachmentFile || !known:
+		if declaredKind != "" && declaredKind != domain.AttachmentFile {
+			return "", "", ErrUnsupportedType
+		}
+		if !safeFileTypes[sniffed] {
+			sniffed = "application/octet-stream"
+		}
+		return sniffed, domain.AttachmentFile, nil
+	case declaredKind != "" && declaredKind != kind:
+		return "", "", ErrUnsupportedType
+	}
+	return sniffed, kind, nil
+}
+
+// CheckSize enforces the per-kind size limit.
+func CheckSize(kind string, size int64) error {
+	if size <= 0 {
+		return ErrEmptyFile
+	}
+	if limit := sizeLimits[kind]; size > limit {
+		return &TooLargeError{Kind: kind, Limit: limit}
+	}
+	return nil
+}
+
+// CheckDuration validates the client-reported length of audio and video.
+// Other kinds have no duration.
+func CheckDuration(kind string, durationMs int64) (int64, error) {
+	limit, timed := maxDurations[kind]
+	if !timed {
+		return 0, nil
+	}
+	if durationMs < 0 || time.Duration(durationMs)*time.Millisecond > limit {
+		return 0, ErrInvalidDuration
+	}
+	return durationMs, nil
+}
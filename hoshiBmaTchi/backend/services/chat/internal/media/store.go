@@ -0,0 +1,137 @@
+package media
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/clients"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/google/uuid"
+)
+
+// PresignTTL is how long a handed-out attachment URL stays valid. Clients
+// get fresh URLs whenever they load history again.
+const PresignTTL = time.Hour
+
+const sniffLen = 512
+
+// Upload is a file received from a client, before it has been checked.
+type Upload struct {
+	UploaderID   uuid.UUID
+	File         io.ReadSeeker
+	FileName     string
+	Size         int64
+	DeclaredKind string
+	DurationMs   int64
+}
+
+// Store validates uploads, keeps them in the private bucket and hands out
+// presigned URLs for attachments.
+type Store struct {
+	minio *clients.MinioClient
+	repo  *repositories.ChatRepository
+}
+
+func NewStore(minio *clients.MinioClient, repo *repositories.ChatRepository) *Store {
+	return &Store{minio: minio, repo: repo}
+}
+
+// Save checks an upload against the attachment rules, stores the object and
+// records the attachment. The attachment stays unclaimed until it is sent in
+// a message.
+func (s *Store) Save(ctx context.Context, up Upload) (*domain.Attachment, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(up.File, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	if _, err := up.File.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	mimeType, kind, err := Classify(head[:n], up.DeclaredKind)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckSize(kind, up.Size); err != nil {
+		return nil, err
+	}
+	durationMs, err := CheckDuration(kind, up.DurationMs)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	objectKey := "attachments/" + up.UploaderID.String() + "/" + id.String() + strings.ToLower(filepath.Ext(up.FileName))
+
+	if err := s.minio.Upload(ctx, objectKey, up.File, up.Size, mimeType); err != nil {
+		return nil, err
+	}
+
+	attachment := &domain.Attachment{
+		ID:         id,
+		UploaderID: up.UploaderID,
+		ObjectKey:  objectKey,
+		Kind:       kind,
+		MimeType:   mimeType,
+		FileName:   filepath.Base(up.FileName),
+		Size:       up.Size,
+		DurationMs: durationMs,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.repo.CreateAttachment(ctx, attachment); err != nil {
+		if rmErr := s.minio.Remove(ctx, objectKey); rmErr != nil {
+			log.Printf("Failed to clean up object %s: %v", objectKey, rmErr)
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// URL returns a presigned link to the attachment. Generic files are served
+// as downloads under their original name.
+func (s *Store) URL(ctx context.Context, a *domain.Attachment) (string, error) {
+	downloadName := ""
+	if a.Kind == domain.AttachmentFile {
+		downloadName = a.FileName
+	}
+	return s.minio.PresignedURL(ctx, a.ObjectKey, downloadName, PresignTTL)
+}
+
+// SignMessage fills MediaURL of a message that carries an attachment.
+func (s *Store) SignMessage(ctx context.Context, msg *domain.Message) {
+	if s == nil || msg.Attachment == nil || msg.IsUnsent {
+		return
+	}
+	url, err := s.URL(ctx, msg.Attachment)
+	if err != nil {
+		log.Printf("Failed to sign attachment %s: %v", msg.Attachment.ID, err)
+		return
+	}
+	msg.MediaURL = url
+}
+
+// Open reads an attachment's file. The caller closes it.
+func (s *Store) Open(ctx context.Context, a *domain.Attachment) (io.ReadCloser, error) {
+	return s.minio.Download(ctx, a.ObjectKey)
+}
+
+// Delete removes an attachment's object from the bucket. Without storage
+// configured there is nothing to delete.
+func (s *Store) Delete(ctx context.Context, objectKey string) error {
+	if s == nil {
+		return nil
+	}
+	return s.minio.Remove(ctx, objectKey)
+}
+
+func (s *Store) SignMessages(ctx context.Context, msgs []domain.Message) {
+	for i := range msgs {
+		s.SignMessage(ctx, &msgs[i])
+	}
+}
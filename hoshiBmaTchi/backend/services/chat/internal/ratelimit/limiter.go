@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit describes a token bucket: Burst tokens at most, refilled at Rate
+// tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute is a limit of n per minute with room for a burst of burst.
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Result is the outcome of taking a token. RetryAfter is set when the
+// bucket was empty.
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// tokenBucketScript refills the bucket for the time passed since it was last
+// touched, then tries to take one token. It uses the Redis clock so every
+// instance sees the same time.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait}`)
+
+// Limiter keeps token buckets in Redis so limits hold across instances.
+type Limiter struct {
+	rdb *redis.Client
+}
+
+func NewLimiter(rdb *redis.Client) *Limiter {
+	return &Limiter{rdb: rdb}
+}
+
+// Allow takes a token from the bucket stored under key.
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := tokenBucketScript.Run(ctx, l.rdb, []string{key}, limit.Rate, limit.Burst).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(res) != 2 {
+		return Result{}, fmt.Errorf("unexpected token bucket reply: %v", res)
+	}
+	return Result{
+		Allowed:    res[0] == 1,
+		RetryAfter: time.Duration(res[1]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCallInProgress        = errors.New("a call is already in progress in this conversation")
+	ErrInvalidCallTransition = errors.New("call is no longer in a state that allows this")
+)
+
+var activeCallStatuses = []string{domain.CallRinging, domain.CallAccepted}
+
+// CreateCall stores a new ringing call. A conversation has at most one live
+// call; the conversation row is locked so two callers cannot both start one.
+func (r *ChatRepository) CreateCall(ctx context.Context, call *domain.Call) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var conv domain.Conversation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&conv, "id = ?", call.ConversationID).Error
+		if err != nil {
+			return err
+		}
+
+		var active int64
+		err = tx.Model(&domain.Call{}).
+			Where("conversation_id = ? AND status IN ?", call.ConversationID, activeCallStatuses).
+			Count(&active).Error
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrCallInProgress
+		}
+
+		return tx.Create(call).Error
+	})
+}
+
+func (r *ChatRepository) GetCall(ctx context.Context, callID string) (*domain.Call, error) {
+	var call domain.Call
+	if err := r.db.WithContext(ctx).First(&call, "id = ?", callID).Error; err != nil {
+		return nil, err
+	}
+	return &call, nil
+}
+
+// ActiveCall returns the live call of a conversation, or nil if there is none.
+func (r *ChatRepository) ActiveCall(ctx context.Context, conversationID string) (*domain.Call, error) {
+	var call domain.Call
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND status IN ?", conversationID, activeCallStatuses).
+		Order("created_at DESC").
+		First(&call).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &call, nil
+}
+
+// TransitionCall moves a call to a new status if it is still in one of the
+// from states. The check happens in the UPDATE itself, so racing requests,
+// such as an accept arriving as the ring times out, cannot both win.
+func (r *ChatRepository) TransitionCall(ctx context.Context, callID string, from []string, to string, at time.Time) (*domain.Call, error) {
+	updates := map[string]interface{}{"status": to}
+	if to == domain.CallAccepted {
+		updates["answered_at"] = at
+	} else {
+		updates["ended_at"] = at
+	}
+
+	result := r.db.WithContext(ctx).Model(&domain.Call{}).
+		Where("id = ? AND status IN ?", callID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInvalidCallTransition
+	}
+	return r.GetCall(ctx, callID)
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// MessageCursor is a keyset position in a conversation's history. Messages
+// are ordered by (created_at, id), so the ID breaks ties between messages
+// stored in the same instant.
+type MessageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (c MessageCursor) Encode() string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeMessageCursor(encoded string) (MessageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return MessageCursor{}, ErrInvalidCursor
+	}
+
+	return MessageCursor{CreatedAt: time.Unix(0, nanos), ID: id}, nil
+}
+
+type PageDirection int
+
+const (
+	PageBefore PageDirection = iota
+	PageAfter
+)
+
+// MessagePageQuery selects one page of history. A nil Cursor starts from the
+// newest message. VisibleFrom hides everything up to the time the reader
+// deleted the conversation on their side, and HiddenSenderIDs drops messages
+// from people the reader has a block with. Offset skips messages past the
+// cursor and only serves clients still paging by offset.
+type MessagePageQuery struct {
+	ConversationID  string
+	Cursor          *MessageCursor
+	Direction       PageDirection
+	Limit           int
+	Offset          int
+	VisibleFrom     *time.Time
+	HiddenSenderIDs []string
+}
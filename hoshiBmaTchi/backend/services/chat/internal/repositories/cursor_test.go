@@ -0,0 +1,51 @@
+package repositories_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+)
+
+func TestMessageCursor_RoundTrip(t *testing.T) {
+	cursor := repositories.MessageCursor{
+		CreatedAt: time.Date(2025, 3, 1, 12, 30, 0, 123456789, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	decoded, err := repositories.DecodeMessageCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodeMessageCursor_RejectsCorruptInput(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	valid := repositories.MessageCursor{CreatedAt: time.Now(), ID: uuid.New()}.Encode()
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!not-base64!!!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("1:2"))},
+		{"no separator", encode("1700000000000000000")},
+		{"bad timestamp", encode("yesterday:" + uuid.NewString())},
+		{"bad id", encode("1700000000000000000:not-a-uuid")},
+		{"empty id", encode("1700000000000000000:")},
+		{"truncated", valid[:len(valid)/2]},
+		{"message id instead of cursor", "9c858901-8a57-4791-81fe-4c455b099bc9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repositories.DecodeMessageCursor(tt.encoded)
+			assert.ErrorIs(t, err, repositories.ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// MarkDelivered records that the message reached the user. It reports false
+// if the delivery was already recorded, e.g. by another of their devices.
+func (r *ChatRepository) MarkDelivered(ctx context.Context, messageID, userID uuid.UUID, at time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.MessageDelivery{MessageID: messageID, UserID: userID, DeliveredAt: at})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EventRecord describes an event to append to several users' logs.
+type EventRecord struct {
+	ConversationID *uuid.UUID
+	MessageID      *uuid.UUID
+	Type           string
+	Payload        []byte
+}
+
+// AppendEvents adds the event to the log of every user and returns the
+// sequence number it got in each. Sequence rows are locked in a fixed order
+// so concurrent appends cannot deadlock, and a later sequence number never
+// commits before an earlier one of the same user.
+func (r *ChatRepository) AppendEvents(ctx context.Context, userIDs []string, rec EventRecord) (map[string]int64, error) {
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, s := range userIDs {
+		id, err := uuid.Parse(s)
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	seqs := make(map[string]int64, len(ids))
+	if len(ids) == 0 {
+		return seqs, nil
+	}
+
+	now := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		events := make([]domain.UserEvent, 0, len(ids))
+		for _, id := range ids {
+			var seq int64
+			err := tx.Raw(`INSERT INTO user_event_sequences (user_id, last_seq) VALUES (?, 1)
+				ON CONFLICT (user_id) DO UPDATE SET last_seq = user_event_sequences.last_seq + 1
+				RETURNING last_seq`, id).Scan(&seq).Error
+			if err != nil {
+				return err
+			}
+			seqs[id.String()] = seq
+			events = append(events, domain.UserEvent{
+				UserID:         id,
+				Seq:            seq,
+				ConversationID: rec.ConversationID,
+				MessageID:      rec.MessageID,
+				Type:           rec.Type,
+				Payload:        string(rec.Payload),
+				CreatedAt:      now,
+			})
+		}
+		return tx.Create(&events).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seqs, nil
+}
+
+// EventsSince returns up to limit of the user's events after seq, oldest
+// first.
+func (r *ChatRepository) EventsSince(ctx context.Context, userID string, seq int64, limit int) ([]domain.UserEvent, error) {
+	var events []domain.UserEvent
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND seq > ?", userID, seq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// LatestEventSeq returns the last sequence number handed out to the user, 0
+// if none.
+func (r *ChatRepository) LatestEventSeq(ctx context.Context, userID string) (int64, error) {
+	var seq int64
+	err := r.db.WithContext(ctx).Model(&domain.UserEventSequence{}).
+		Select("COALESCE(MAX(last_seq), 0)").
+		Where("user_id = ?", userID).
+		Scan(&seq).Error
+	return seq, err
+}
+
+// PruneEvents deletes up to limit events older than before and returns how
+// many went.
+func (r *ChatRepository) PruneEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	res := r.db.WithContext(ctx).Exec(`DELETE FROM user_events WHERE ctid IN (
+		SELECT ctid FROM user_events WHERE created_at < ? LIMIT ?)`, before, limit)
+	return res.RowsAffected, res.Error
+}
+
+// redactEvents blanks logged frames of messages that are being deleted, down
+// to what a client needs to drop them. The events stay so sequences have no
+// gaps; a gap means the log was pruned.
+func redactEvents(tx *gorm.DB, query string, args ...interface{}) error {
+	return tx.Model(&domain.UserEvent{}).Where(query, args...).
+		Update("payload", gorm.Expr(`json_build_object(
+			'type', type, 'id', message_id, 'conversation_id', conversation_id, 'redacted', true)::text`)).Error
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// notExpired hides messages whose time is up but that the sweeper has not
+// deleted yet.
+func notExpired(db *gorm.DB) *gorm.DB {
+	return db.Where("expires_at IS NULL OR expires_at > ?", time.Now())
+}
+
+// SetDisappearing changes a conversation's disappearing-messages timer. Either
+// side of a direct conversation may change it; in groups only admins can.
+func (r *ChatRepository) SetDisappearing(ctx context.Context, conversationID, actorID string, after time.Duration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		participant, err := getParticipant(tx, conversationID, actorID)
+		if err != nil {
+			return err
+		}
+
+		var conv domain.Conversation
+		if err := tx.First(&conv, "id = ?", conversationID).Error; err != nil {
+			return err
+		}
+		if conv.IsGroup && !participant.IsAdmin() {
+			return ErrNotAdmin
+		}
+
+		return tx.Model(&conv).Update("disappear_after", int64(after/time.Second)).Error
+	})
+}
+
+// ExpiredMessage is a message due for deletion.
+type ExpiredMessage struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+}
+
+// ExpiredMessages returns up to limit messages that expired before now,
+// oldest first.
+func (r *ChatRepository) ExpiredMessages(ctx context.Context, now time.Time, limit int) ([]ExpiredMessage, error) {
+	var expired []ExpiredMessage
+	err := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Select("id, conversation_id").
+		Where("expires_at <= ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Scan(&expired).Error
+	return expired, err
+}
+
+// DeleteMessages hard-deletes messages with their reactions, edit history,
+// delivery receipts and attachment rows, and redacts their logged events.
+// Read pointers on a deleted message move back to the newest older message
+// that survives, so unread counts stay right. It returns the object keys of
+// the deleted attachments, for the caller to remove from storage.
+func (r *ChatRepository) DeleteMessages(ctx context.Context, ids []uuid.UUID) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var objectKeys []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE participants p SET last_read_message_id = (
+				SELECT m.id FROM messages m, messages lr
+				WHERE lr.id = p.last_read_message_id
+					AND m.conversation_id = p.conversation_id
+					AND m.id NOT IN ?
+					AND m.created_at <= lr.created_at
+				ORDER BY m.created_at DESC, m.id DESC
+				LIMIT 1)
+			WHERE p.last_read_message_id IN ?`, ids, ids).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("message_id IN ?", ids).Delete(&domain.MessageReaction{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id IN ?", ids).Delete(&domain.MessageEdit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id IN ?", ids).Delete(&domain.MessageDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := redactEvents(tx, "message_id IN ?", ids); err != nil {
+			return err
+		}
+		objectKeys, err = deleteAttachments(tx, "message_id IN ?", ids)
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&domain.Message{}).Error
+	})
+	return objectKeys, err
+}
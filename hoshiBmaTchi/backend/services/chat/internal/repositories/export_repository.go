@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrExportInProgress = errors.New("an export is already in progress")
+
+// CreateExportJob queues an export for the user. A user has at most one
+// export pending or running at a time.
+func (r *ChatRepository) CreateExportJob(ctx context.Context, userID string) (*domain.ExportJob, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, ErrNotParticipant
+	}
+
+	job := &domain.ExportJob{
+		ID:        uuid.New(),
+		UserID:    uid,
+		Status:    domain.ExportPending,
+		CreatedAt: time.Now(),
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialise concurrent requests of the same user.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "chat_export:"+userID).Error; err != nil {
+			return err
+		}
+
+		var active int64
+		err := tx.Model(&domain.ExportJob{}).
+			Where("user_id = ? AND status IN ?", uid, []string{domain.ExportPending, domain.ExportRunning}).
+			Count(&active).Error
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrExportInProgress
+		}
+		return tx.Create(job).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetExportJob returns one of the user's export jobs.
+func (r *ChatRepository) GetExportJob(ctx context.Context, jobID, userID string) (*domain.ExportJob, error) {
+	var job domain.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", jobID, userID).
+		First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListExportJobs returns the user's most recent export jobs, newest first.
+func (r *ChatRepository) ListExportJobs(ctx context.Context, userID string, limit int) ([]domain.ExportJob, error) {
+	var jobs []domain.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimExportJob marks the oldest waiting job as running and returns it, or
+// nil if there is none. A job left running for longer than stuckAfter
+// belonged to a worker that went away and is handed out again. SKIP LOCKED
+// keeps two instances from claiming the same job.
+func (r *ChatRepository) ClaimExportJob(ctx context.Context, now time.Time, stuckAfter time.Duration) (*domain.ExportJob, error) {
+	var job domain.ExportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND started_at < ?)",
+				domain.ExportPending, domain.ExportRunning, now.Add(-stuckAfter)).
+			Order("created_at ASC").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		job.Status = domain.ExportRunning
+		job.StartedAt = &now
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":     job.Status,
+			"started_at": now,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// SaveExportJob stores the outcome of a job.
+func (r *ChatRepository) SaveExportJob(ctx context.Context, job *domain.ExportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// ExpiredExportJobs returns completed jobs whose archive is past its expiry
+// and still stored.
+func (r *ChatRepository) ExpiredExportJobs(ctx context.Context, now time.Time, limit int) ([]domain.ExportJob, error) {
+	var jobs []domain.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ? AND object_key <> ''", domain.ExportCompleted, now).
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// ExportConversation is a conversation as one user sees it, for an export.
+type ExportConversation struct {
+	Conversation domain.Conversation
+	HiddenAt     *time.Time
+}
+
+// ExportConversations returns every conversation the user belongs to with
+// all its participants, including archived ones and message requests.
+func (r *ChatRepository) ExportConversations(ctx context.Context, userID string) ([]ExportConversation, error) {
+	var own []domain.Participant
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&own).Error; err != nil {
+		return nil, err
+	}
+	if len(own) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(own))
+	hiddenAt := make(map[uuid.UUID]*time.Time, len(own))
+	for _, p := range own {
+		ids = append(ids, p.ConversationID)
+		hiddenAt[p.ConversationID] = p.HiddenAt
+	}
+
+	var convs []domain.Conversation
+	err := r.db.WithContext(ctx).
+		Preload("Participants").
+		Where("id IN ?", ids).
+		Order("created_at ASC").
+		Find(&convs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ExportConversation, 0, len(convs))
+	for _, conv := range convs {
+		result = append(result, ExportConversation{Conversation: conv, HiddenAt: hiddenAt[conv.ID]})
+	}
+	return result, nil
+}
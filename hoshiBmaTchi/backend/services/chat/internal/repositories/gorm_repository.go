@@ -3,11 +3,31 @@ package repositories
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	editWindow        = 15 * time.Minute
+	maxReactionLength = 32
+)
+
+var (
+	ErrNotParticipant     = errors.New("user is not a participant of this conversation")
+	ErrNotMessageSender   = errors.New("only the sender can change this message")
+	ErrMessageUnsent      = errors.New("message has been unsent")
+	ErrMessageNotEditable = errors.New("this message cannot be edited")
+	ErrEditWindowExpired  = errors.New("cannot edit message older than 15 minutes")
+	ErrEmptyMessage       = errors.New("message content is empty")
+	ErrInvalidReaction    = errors.New("invalid reaction")
+	ErrInvalidReplyTarget = errors.New("replied message is not in this conversation")
+	ErrInvalidAttachment  = errors.New("attachment not found or already sent")
+	ErrDuplicateMessage   = errors.New("message was already sent")
 )
 
 type ChatRepository struct {
@@ -18,8 +38,280 @@ func NewChatRepository(db *gorm.DB) *ChatRepository {
 	return &ChatRepository{db: db}
 }
 
+// SaveMessage stores a new message. If the sender already sent one with the
+// same ClientMsgID, msg is replaced by the stored message and
+// ErrDuplicateMessage is returned.
 func (r *ChatRepository) SaveMessage(ctx context.Context, msg *domain.Message) error {
-	return r.db.Create(msg).Error
+	if msg.ClientMsgID != nil {
+		existing, err := r.MessageByClientMsgID(ctx, msg.SenderID, *msg.ClientMsgID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			*msg = *existing
+			return ErrDuplicateMessage
+		}
+	}
+
+	err := r.saveMessage(ctx, msg)
+	if errors.Is(err, ErrDuplicateMessage) {
+		// Lost a race against the same resend on another connection.
+		existing, lookupErr := r.MessageByClientMsgID(ctx, msg.SenderID, *msg.ClientMsgID)
+		if lookupErr != nil || existing == nil {
+			return lookupErr
+		}
+		*msg = *existing
+	}
+	return err
+}
+
+// MessageByClientMsgID finds a message by the ID its sender gave it, or
+// returns nil.
+func (r *ChatRepository) MessageByClientMsgID(ctx context.Context, senderID uuid.UUID, clientMsgID string) (*domain.Message, error) {
+	var msg domain.Message
+	err := r.db.WithContext(ctx).Preload("Attachment").
+		Where("sender_id = ? AND client_msg_id = ?", senderID, clientMsgID).
+		First(&msg).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (r *ChatRepository) saveMessage(ctx context.Context, msg *domain.Message) error {
+	if msg.ReplyToID != nil {
+		var count int64
+		err := r.db.WithContext(ctx).Model(&domain.Message{}).
+			Where("id = ? AND conversation_id = ?", *msg.ReplyToID, msg.ConversationID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrInvalidReplyTarget
+		}
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if msg.MediaType != domain.MediaTypeSystem {
+			if err := setExpiry(tx, msg); err != nil {
+				return err
+			}
+		}
+
+		var attachment *domain.Attachment
+		if msg.AttachmentID != nil {
+			var err error
+			if attachment, err = unclaimedAttachment(tx, msg); err != nil {
+				return err
+			}
+			msg.MediaType = attachment.Kind
+			msg.MediaURL = ""
+		}
+
+		res := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(msg)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDuplicateMessage
+		}
+
+		if attachment != nil {
+			if err := tx.Model(attachment).Update("message_id", msg.ID).Error; err != nil {
+				return err
+			}
+			msg.Attachment = attachment
+		}
+
+		// Replying to a message request accepts it.
+		return tx.Model(&domain.Participant{}).
+			Where("conversation_id = ? AND user_id = ? AND is_request", msg.ConversationID, msg.SenderID).
+			Update("is_request", false).Error
+	})
+}
+
+// setExpiry stamps the message with its disappearing time if the
+// conversation has a timer on. System messages never disappear, so changes
+// to the timer stay visible.
+func setExpiry(tx *gorm.DB, msg *domain.Message) error {
+	var disappearAfter int64
+	err := tx.Model(&domain.Conversation{}).
+		Where("id = ?", msg.ConversationID).
+		Pluck("disappear_after", &disappearAfter).Error
+	if err != nil {
+		return err
+	}
+	if disappearAfter > 0 {
+		expiresAt := msg.CreatedAt.Add(time.Duration(disappearAfter) * time.Second)
+		msg.ExpiresAt = &expiresAt
+	}
+	return nil
+}
+
+// unclaimedAttachment locks the attachment a new message wants to send. Only
+// the uploader can send it, and only once.
+func unclaimedAttachment(tx *gorm.DB, msg *domain.Message) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND uploader_id = ? AND message_id IS NULL", *msg.AttachmentID, msg.SenderID).
+		First(&attachment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidAttachment
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *ChatRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) error {
+	return r.db.WithContext(ctx).Create(attachment).Error
+}
+
+func (r *ChatRepository) IsParticipant(ctx context.Context, conversationID, userID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Participant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// AddReaction sets the user's reaction on a message, replacing any earlier
+// one. It returns the message so callers know which conversation to notify.
+func (r *ChatRepository) AddReaction(ctx context.Context, messageID, userID, emoji string) (*domain.Message, error) {
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" || len(emoji) > maxReactionLength || strings.ContainsAny(emoji, " \t\n") {
+		return nil, ErrInvalidReaction
+	}
+
+	reactorID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, ErrNotParticipant
+	}
+
+	msg, err := r.reactableMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := domain.MessageReaction{
+		MessageID: msg.ID,
+		UserID:    reactorID,
+		Emoji:     emoji,
+		CreatedAt: time.Now(),
+	}
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"emoji", "created_at"}),
+	}).Create(&reaction).Error
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (r *ChatRepository) RemoveReaction(ctx context.Context, messageID, userID string) (*domain.Message, error) {
+	msg, err := r.reactableMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Where("message_id = ? AND user_id = ?", messageID, userID).
+		Delete(&domain.MessageReaction{}).Error
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (r *ChatRepository) reactableMessage(ctx context.Context, messageID, userID string) (*domain.Message, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrNotParticipant
+	}
+
+	msg, err := r.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.IsUnsent {
+		return nil, ErrMessageUnsent
+	}
+
+	isMember, err := r.IsParticipant(ctx, msg.ConversationID.String(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotParticipant
+	}
+	return msg, nil
+}
+
+// EditMessage replaces the content of a message within the edit window and
+// records the previous content in the message's edit history.
+func (r *ChatRepository) EditMessage(ctx context.Context, messageID, userID, content string) (*domain.Message, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	var msg domain.Message
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&msg, "id = ?", messageID).Error; err != nil {
+			return err
+		}
+
+		if msg.SenderID.String() != userID {
+			return ErrNotMessageSender
+		}
+		if msg.IsUnsent {
+			return ErrMessageUnsent
+		}
+		if msg.IsShare() || msg.MediaType == domain.MediaTypeSystem {
+			return ErrMessageNotEditable
+		}
+		if time.Since(msg.CreatedAt) > editWindow {
+			return ErrEditWindowExpired
+		}
+		if msg.Content == content {
+			return nil
+		}
+
+		now := time.Now()
+		edit := domain.MessageEdit{
+			ID:              uuid.New(),
+			MessageID:       msg.ID,
+			PreviousContent: msg.Content,
+			EditedAt:        now,
+		}
+		if err := tx.Create(&edit).Error; err != nil {
+			return err
+		}
+
+		msg.Content = content
+		msg.EditedAt = &now
+		return tx.Model(&msg).Updates(map[string]interface{}{
+			"content":   content,
+			"edited_at": now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (r *ChatRepository) GetEditHistory(ctx context.Context, messageID string) ([]domain.MessageEdit, error) {
+	var edits []domain.MessageEdit
+	err := r.db.WithContext(ctx).
+		Where("message_id = ?", messageID).
+		Order("edited_at ASC").
+		Find(&edits).Error
+	return edits, err
 }
 
 func (r *ChatRepository) UnsendMessage(ctx context.Context, messageID, userID string) error {
@@ -39,7 +331,12 @@ func (r *ChatRepository) UnsendMessage(ctx context.Context, messageID, userID st
 	msg.IsUnsent = true
 	msg.Content = "This message was unsent"
 	msg.MediaURL = ""
-	return r.db.Save(&msg).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&msg).Error; err != nil {
+			return err
+		}
+		return redactEvents(tx, "message_id = ?", msg.ID)
+	})
 }
 
 func (r *ChatRepository) CreateConversation(ctx context.Context, conv *domain.Conversation, userIDs []string) error {
@@ -48,16 +345,24 @@ func (r *ChatRepository) CreateConversation(ctx context.Context, conv *domain.Co
 			return err
 		}
 		
+		seen := make(map[uuid.UUID]bool)
 		for _, uid := range userIDs {
 			parsedUUID, err := uuid.Parse(uid)
-			if err != nil {
+			if err != nil || seen[parsedUUID] {
 				continue 
 			}
+			seen[parsedUUID] = true
+
+			role := domain.RoleMember
+			if conv.IsGroup && conv.CreatedBy != nil && *conv.CreatedBy == parsedUUID {
+				role = domain.RoleOwner
+			}
 
 			p := domain.Participant{
 				ConversationID: conv.ID,
 				UserID:         parsedUUID,
 				JoinedAt:       time.Now(),
+				Role:           role,
 			}
 			if err := tx.Create(&p).Error; err != nil {
 				return err
@@ -67,63 +372,279 @@ func (r *ChatRepository) CreateConversation(ctx context.Context, conv *domain.Co
 	})
 }
 
-func (r *ChatRepository) GetConversations(ctx context.Context, userID string) ([]domain.Conversation, error) {
+func (r *ChatRepository) GetConversations(ctx context.Context, userID string, filter ConversationFilter) ([]domain.Conversation, error) {
 	var conversations []domain.Conversation
-	
-	subQuery := r.db.Table("participants").Select("conversation_id").Where("user_id = ?", userID)
 
-	err := r.db.Preload("Participants").
-		Where("id IN (?)", subQuery).
-		Order("created_at DESC").
+	// Conversations the user deleted for themselves stay out of the list until
+	// someone writes in them again. Pinned conversations come first, then the
+	// rest by their latest message.
+	err := r.db.WithContext(ctx).Preload("Participants").
+		Joins("JOIN participants p ON p.conversation_id = conversations.id AND p.user_id = ?", userID).
+		Where("p.is_request = ? AND p.is_archived = ?", filter.Requests, filter.Archived).
+		Where(`p.hidden_at IS NULL OR EXISTS (
+			SELECT 1 FROM messages m
+			WHERE m.conversation_id = p.conversation_id AND m.created_at > p.hidden_at)`).
+		Order("p.is_pinned DESC").
+		Order(`COALESCE((SELECT MAX(m.created_at) FROM messages m
+			WHERE m.conversation_id = conversations.id), conversations.created_at) DESC`).
 		Find(&conversations).Error
+	if err != nil {
+		return nil, err
+	}
 
-	return conversations, err
+	if err := r.attachSummaries(ctx, userID, conversations); err != nil {
+		return nil, err
+	}
+
+	return conversations, nil
 }
 
-func (r *ChatRepository) GetMessageHistory(ctx context.Context, conversationID string, limit, offset int) ([]domain.Message, error) {
-	var messages []domain.Message
-	
-	err := r.db.Where("conversation_id = ?", conversationID).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
-		Find(&messages).Error
-		
-	return messages, err
+type conversationSummary struct {
+	ConversationID uuid.UUID
+	UnreadCount    int64
 }
 
-func (r *ChatRepository) AddParticipant(ctx context.Context, conversationID, userID string) error {
+// attachSummaries fills the last message preview, the caller's unread count
+// and the caller's inbox settings of each conversation. Unread messages are
+// the ones from other participants created after the caller's read pointer.
+func (r *ChatRepository) attachSummaries(ctx context.Context, userID string, conversations []domain.Conversation) error {
+	if len(conversations) == 0 {
+		return nil
+	}
 
-	cID, err := uuid.Parse(conversationID)
-	if err != nil { return err }
-	uID, err := uuid.Parse(userID)
-	if err != nil { return err }
+	ids := make([]uuid.UUID, len(conversations))
+	for i, c := range conversations {
+		ids[i] = c.ID
+	}
 
-	p := domain.Participant{
-		ConversationID: cID,
-		UserID:         uID,
-		JoinedAt:       time.Now(),
+	var lastMessages []domain.Message
+	err := r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (conversation_id) * FROM messages
+			WHERE conversation_id IN ?
+				AND (expires_at IS NULL OR expires_at > now())
+			ORDER BY conversation_id, created_at DESC, id DESC`, ids).
+		Scan(&lastMessages).Error
+	if err != nil {
+		return err
 	}
-	return r.db.Create(&p).Error
+
+	var summaries []conversationSummary
+	err = r.db.WithContext(ctx).
+		Raw(`SELECT p.conversation_id, COUNT(m.id) AS unread_count
+			FROM participants p
+			LEFT JOIN messages lr ON lr.id = p.last_read_message_id
+			LEFT JOIN messages m ON m.conversation_id = p.conversation_id
+				AND m.sender_id <> p.user_id
+				AND (lr.id IS NULL OR m.created_at > lr.created_at)
+				AND (p.hidden_at IS NULL OR m.created_at > p.hidden_at)
+				AND (m.expires_at IS NULL OR m.expires_at > now())
+			WHERE p.user_id = ? AND p.conversation_id IN ?
+			GROUP BY p.conversation_id`, userID, ids).
+		Scan(&summaries).Error
+	if err != nil {
+		return err
+	}
+
+	lastByConv := make(map[uuid.UUID]domain.Message, len(lastMessages))
+	for _, m := range lastMessages {
+		lastByConv[m.ConversationID] = m
+	}
+	unreadByConv := make(map[uuid.UUID]int64, len(summaries))
+	for _, s := range summaries {
+		unreadByConv[s.ConversationID] = s.UnreadCount
+	}
+
+	now := time.Now()
+	for i := range conversations {
+		c := &conversations[i]
+		if m, ok := lastByConv[c.ID]; ok {
+			createdAt := m.CreatedAt
+			c.LastMessage = m.Preview()
+			c.LastMessageAt = &createdAt
+		}
+		c.UnreadCount = unreadByConv[c.ID]
+		for _, p := range c.Participants {
+			if p.UserID.String() == userID {
+				c.IsPinned = p.IsPinned
+				c.IsArchived = p.IsArchived
+				if p.IsMuted(now) {
+					c.MutedUntil = p.MutedUntil
+				}
+			}
+		}
+	}
+	return nil
 }
 
-func (r *ChatRepository) RemoveParticipant(ctx context.Context, conversationID, userID string) error {
-	return r.db.Where("conversation_id = ? AND user_id = ?", conversationID, userID).
-		Delete(&domain.Participant{}).Error
+// ResolveCursor accepts either an encoded cursor or a message ID from the
+// conversation and returns the keyset position it points at.
+func (r *ChatRepository) ResolveCursor(ctx context.Context, conversationID, value string) (*MessageCursor, error) {
+	if _, err := uuid.Parse(value); err == nil {
+		var msg domain.Message
+		err := r.db.WithContext(ctx).
+			Select("id", "created_at").
+			First(&msg, "id = ? AND conversation_id = ?", value, conversationID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrInvalidCursor
+			}
+			return nil, err
+		}
+		return &MessageCursor{CreatedAt: msg.CreatedAt, ID: msg.ID}, nil
+	}
+
+	cursor, err := DecodeMessageCursor(value)
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
 }
 
-func (r *ChatRepository) MarkConversationAsRead(ctx context.Context, conversationID, userID, messageID string) error {
-	return r.db.Model(&domain.Participant{}).
-		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
-		Update("last_read_message_id", messageID).Error
+// NewMessagePageQuery builds a page query from request parameters. before and
+// after are mutually exclusive; each takes a cursor or a message ID.
+func (r *ChatRepository) NewMessagePageQuery(ctx context.Context, conversationID, before, after string, limit int) (MessagePageQuery, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+
+	q := MessagePageQuery{ConversationID: conversationID, Direction: PageBefore, Limit: limit}
+
+	if before != "" && after != "" {
+		return q, ErrInvalidCursor
+	}
+
+	value := before
+	if after != "" {
+		value = after
+		q.Direction = PageAfter
+	}
+	if value == "" {
+		return q, nil
+	}
+
+	cursor, err := r.ResolveCursor(ctx, conversationID, value)
+	if err != nil {
+		return q, err
+	}
+	q.Cursor = cursor
+	return q, nil
 }
 
-func (r *ChatRepository) SearchMessages(ctx context.Context, conversationID, query string) ([]domain.Message, error) {
+// GetMessagePage returns one page of history using keyset pagination on
+// (created_at, id). PageBefore walks towards older messages and returns them
+// newest first; PageAfter walks towards newer ones and returns them oldest
+// first. The returned cursor points past the last message of the page and is
+// empty once there is nothing left in that direction.
+func (r *ChatRepository) GetMessagePage(ctx context.Context, q MessagePageQuery) ([]domain.Message, string, error) {
+	query := r.db.WithContext(ctx).Preload("Reactions").Preload("Attachment").
+		Where("conversation_id = ?", q.ConversationID).
+		Scopes(notExpired)
+	if q.VisibleFrom != nil {
+		query = query.Where("created_at > ?", *q.VisibleFrom)
+	}
+	if len(q.HiddenSenderIDs) > 0 {
+		query = query.Where("sender_id NOT IN ?", q.HiddenSenderIDs)
+	}
+
+	if q.Direction == PageAfter {
+		if q.Cursor != nil {
+			query = query.Where("(created_at, id) > (?, ?)", q.Cursor.CreatedAt, q.Cursor.ID)
+		}
+		query = query.Order("created_at ASC, id ASC")
+	} else {
+		if q.Cursor != nil {
+			query = query.Where("(created_at, id) < (?, ?)", q.Cursor.CreatedAt, q.Cursor.ID)
+		}
+		query = query.Order("created_at DESC, id DESC")
+	}
+
 	var messages []domain.Message
-	err := r.db.Where("conversation_id = ? AND content ILIKE ?", conversationID, "%"+query+"%").
-		Order("created_at DESC").
-		Find(&messages).Error
-	return messages, err
+	if err := query.Limit(q.Limit + 1).Offset(q.Offset).Find(&messages).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(messages) > q.Limit {
+		messages = messages[:q.Limit]
+		last := messages[len(messages)-1]
+		nextCursor = MessageCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return messages, nextCursor, nil
+}
+
+func (r *ChatRepository) GetParticipantIDs(ctx context.Context, conversationID string) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Model(&domain.Participant{}).
+		Where("conversation_id = ?", conversationID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *ChatRepository) GetLatestMessage(ctx context.Context, conversationID string) (*domain.Message, error) {
+	var msg domain.Message
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ?", conversationID).
+		Order("created_at DESC, id DESC").
+		First(&msg).Error
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// MarkConversationAsRead moves the user's read pointer to messageID. The
+// pointer only moves forward, so a late receipt from another device cannot
+// mark already read messages as unread again.
+func (r *ChatRepository) MarkConversationAsRead(ctx context.Context, conversationID, userID, messageID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var p domain.Participant
+		if err := tx.First(&p, "conversation_id = ? AND user_id = ?", conversationID, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotParticipant
+			}
+			return err
+		}
+
+		var msg domain.Message
+		if err := tx.First(&msg, "id = ? AND conversation_id = ?", messageID, conversationID).Error; err != nil {
+			return err
+		}
+
+		if p.LastReadMessageID != nil {
+			var current domain.Message
+			err := tx.First(&current, "id = ?", *p.LastReadMessageID).Error
+			if err == nil && !current.CreatedAt.Before(msg.CreatedAt) {
+				return nil
+			}
+		}
+
+		return tx.Model(&domain.Participant{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+			Update("last_read_message_id", msg.ID).Error
+	})
+}
+
+// GetContactIDs returns everyone who shares at least one conversation with
+// the user.
+func (r *ChatRepository) GetContactIDs(ctx context.Context, userID string) ([]string, error) {
+	var userIDs []string
+	// Conversations the user deleted for themselves stay out of the list until
+	// someone writes in them again.
+	subQuery := r.db.Table("participants").Select("conversation_id").
+		Where("user_id = ?", userID).
+		Where(`hidden_at IS NULL OR EXISTS (
+			SELECT 1 FROM messages m
+			WHERE m.conversation_id = participants.conversation_id AND m.created_at > participants.hidden_at)`)
+
+	err := r.db.WithContext(ctx).Model(&domain.Participant{}).
+		Distinct("user_id").
+		Where("conversation_id IN (?) AND user_id <> ?", subQuery, userID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
 }
 
 func (r *ChatRepository) FindDirectConversation(ctx context.Context, user1ID, user2ID string) (*domain.Conversation, error) {
@@ -142,20 +663,88 @@ func (r *ChatRepository) FindDirectConversation(ctx context.Context, user1ID, us
 	return &conversation, nil
 }
 
-func (r *ChatRepository) DeleteConversation(ctx context.Context, conversationID string) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("conversation_id = ?", conversationID).Delete(&domain.Message{}).Error; err != nil {
-			return err
-		}
-
-		if err := tx.Where("conversation_id = ?", conversationID).Delete(&domain.Participant{}).Error; err != nil {
-			return err
-		}
+func (r *ChatRepository) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	var conv domain.Conversation
+	if err := r.db.WithContext(ctx).First(&conv, "id = ?", conversationID).Error; err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
 
-		if err := tx.Where("id = ?", conversationID).Delete(&domain.Conversation{}).Error; err != nil {
-			return err
-		}
+func (r *ChatRepository) GetMessage(ctx context.Context, messageID string) (*domain.Message, error) {
+	var msg domain.Message
+	if err := r.db.WithContext(ctx).First(&msg, "id = ?", messageID).Error; err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
 
-		return nil
+// DeleteConversation deletes a conversation with everything in it. It
+// returns the object keys of its attachments, for the caller to remove from
+// storage.
+func (r *ChatRepository) DeleteConversation(ctx context.Context, conversationID string) ([]string, error) {
+	var objectKeys []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		objectKeys, err = deleteConversation(tx, conversationID)
+		return err
 	})
-}
\ No newline at end of file
+	return objectKeys, err
+}
+
+// deleteConversation deletes a conversation inside tx and returns the object
+// keys of its attachments. Objects are only removed once tx commits, so a
+// rollback never leaves messages pointing at missing files.
+func deleteConversation(tx *gorm.DB, conversationID string) ([]string, error) {
+	messageIDs := tx.Model(&domain.Message{}).Select("id").Where("conversation_id = ?", conversationID)
+
+	if err := tx.Where("message_id IN (?)", messageIDs).Delete(&domain.MessageReaction{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("message_id IN (?)", messageIDs).Delete(&domain.MessageEdit{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("message_id IN (?)", messageIDs).Delete(&domain.MessageDelivery{}).Error; err != nil {
+		return nil, err
+	}
+
+	objectKeys, err := deleteAttachments(tx, "message_id IN (?)", messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := redactEvents(tx, "conversation_id = ?", conversationID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("conversation_id = ?", conversationID).Delete(&domain.Message{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("conversation_id = ?", conversationID).Delete(&domain.Participant{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("id = ?", conversationID).Delete(&domain.Conversation{}).Error; err != nil {
+		return nil, err
+	}
+	return objectKeys, nil
+}
+
+// deleteAttachments deletes the attachment rows matching query and returns
+// their object keys.
+func deleteAttachments(tx *gorm.DB, query string, args ...interface{}) ([]string, error) {
+	var objectKeys []string
+	if err := tx.Model(&domain.Attachment{}).Where(query, args...).Pluck("object_key", &objectKeys).Error; err != nil {
+		return nil, err
+	}
+	if len(objectKeys) == 0 {
+		return nil, nil
+	}
+	if err := tx.Where(query, args...).Delete(&domain.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+	return objectKeys, nil
+}
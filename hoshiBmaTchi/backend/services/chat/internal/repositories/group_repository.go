@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotGroup           = errors.New("this action is only available in group chats")
+	ErrNotAdmin           = errors.New("only group admins can do this")
+	ErrNotOwner           = errors.New("only the group owner can do this")
+	ErrAlreadyParticipant = errors.New("user is already a participant")
+	ErrCannotRemoveOwner  = errors.New("the group owner cannot be removed")
+	ErrUseLeave           = errors.New("use leave to remove yourself from a group")
+	ErrInvalidRole        = errors.New("role must be admin or member")
+	ErrInvalidGroupName   = errors.New("group name cannot be empty")
+)
+
+func (r *ChatRepository) GetParticipant(ctx context.Context, conversationID, userID string) (*domain.Participant, error) {
+	return getParticipant(r.db.WithContext(ctx), conversationID, userID)
+}
+
+func getParticipant(tx *gorm.DB, conversationID, userID string) (*domain.Participant, error) {
+	var p domain.Participant
+	if err := tx.First(&p, "conversation_id = ? AND user_id = ?", conversationID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotParticipant
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// lockGroupForAdmin loads a group conversation for update and checks that the
+// actor is one of its admins.
+func lockGroupForAdmin(tx *gorm.DB, conversationID, actorID string) (*domain.Conversation, *domain.Participant, error) {
+	var conv domain.Conversation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&conv, "id = ?", conversationID).Error; err != nil {
+		return nil, nil, err
+	}
+	if !conv.IsGroup {
+		return nil, nil, ErrNotGroup
+	}
+
+	actor, err := getParticipant(tx, conversationID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !actor.IsAdmin() {
+		return nil, nil, ErrNotAdmin
+	}
+	return &conv, actor, nil
+}
+
+func (r *ChatRepository) AddParticipant(ctx context.Context, conversationID, actorID, userID string) error {
+	uID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		conv, _, err := lockGroupForAdmin(tx, conversationID, actorID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := getParticipant(tx, conversationID, userID); err == nil {
+			return ErrAlreadyParticipant
+		} else if !errors.Is(err, ErrNotParticipant) {
+			return err
+		}
+
+		p := domain.Participant{
+			ConversationID: conv.ID,
+			UserID:         uID,
+			JoinedAt:       time.Now(),
+			Role:           domain.RoleMember,
+		}
+		return tx.Create(&p).Error
+	})
+}
+
+// RemoveParticipant kicks a member out of a group. Admins can remove
+// members; only the owner can remove another admin.
+func (r *ChatRepository) RemoveParticipant(ctx context.Context, conversationID, actorID, userID string) error {
+	if actorID == userID {
+		return ErrUseLeave
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, actor, err := lockGroupForAdmin(tx, conversationID, actorID)
+		if err != nil {
+			return err
+		}
+
+		target, err := getParticipant(tx, conversationID, userID)
+		if err != nil {
+			return err
+		}
+		if target.Role == domain.RoleOwner {
+			return ErrCannotRemoveOwner
+		}
+		if target.Role == domain.RoleAdmin && actor.Role != domain.RoleOwner {
+			return ErrNotOwner
+		}
+
+		return tx.Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+			Delete(&domain.Participant{}).Error
+	})
+}
+
+// UpdateGroupDetails renames a group and/or changes its avatar. Nil fields
+// are left untouched.
+func (r *ChatRepository) UpdateGroupDetails(ctx context.Context, conversationID, actorID string, name, avatarURL *string) (*domain.Conversation, error) {
+	var conv *domain.Conversation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		conv, _, err = lockGroupForAdmin(tx, conversationID, actorID)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if name != nil {
+			trimmed := strings.TrimSpace(*name)
+			if trimmed == "" {
+				return ErrInvalidGroupName
+			}
+			conv.Name = trimmed
+			updates["name"] = trimmed
+		}
+		if avatarURL != nil {
+			conv.AvatarURL = *avatarURL
+			updates["avatar_url"] = *avatarURL
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(conv).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
+
+// SetParticipantRole promotes a member to admin or demotes an admin. Only the
+// owner can change roles, and the owner's own role is fixed.
+func (r *ChatRepository) SetParticipantRole(ctx context.Context, conversationID, actorID, userID, role string) error {
+	if role != domain.RoleAdmin && role != domain.RoleMember {
+		return ErrInvalidRole
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, actor, err := lockGroupForAdmin(tx, conversationID, actorID)
+		if err != nil {
+			return err
+		}
+		if actor.Role != domain.RoleOwner {
+			return ErrNotOwner
+		}
+
+		target, err := getParticipant(tx, conversationID, userID)
+		if err != nil {
+			return err
+		}
+		if target.Role == domain.RoleOwner {
+			return ErrInvalidRole
+		}
+
+		return tx.Model(&domain.Participant{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+			Update("role", role).Error
+	})
+}
+
+// LeaveConversation removes the user from a group. When the owner leaves,
+// ownership passes to the longest-standing admin, or failing that the
+// longest-standing member. The returned ID is the new owner, if any. A group
+// whose last member leaves is deleted, and the object keys of its attachments
+// are returned for the caller to remove from storage.
+func (r *ChatRepository) LeaveConversation(ctx context.Context, conversationID, userID string) (newOwnerID string, objectKeys []string, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var conv domain.Conversation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&conv, "id = ?", conversationID).Error; err != nil {
+			return err
+		}
+		if !conv.IsGroup {
+			return ErrNotGroup
+		}
+
+		leaving, err := getParticipant(tx, conversationID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+			Delete(&domain.Participant{}).Error; err != nil {
+			return err
+		}
+
+		var successor domain.Participant
+		err = tx.Where("conversation_id = ?", conversationID).
+			Order("CASE WHEN role = 'admin' THEN 0 ELSE 1 END, joined_at ASC").
+			First(&successor).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			objectKeys, err = deleteConversation(tx, conversationID)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if leaving.Role != domain.RoleOwner {
+			return nil
+		}
+
+		newOwnerID = successor.UserID.String()
+		return tx.Model(&domain.Participant{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, successor.UserID).
+			Update("role", domain.RoleOwner).Error
+	})
+	return newOwnerID, objectKeys, err
+}
+
+// HideConversation deletes a conversation for one user only. The other
+// participants keep their history.
+func (r *ChatRepository) HideConversation(ctx context.Context, conversationID, userID string) error {
+	if _, err := r.GetParticipant(ctx, conversationID, userID); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Model(&domain.Participant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Update("hidden_at", time.Now()).Error
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrNoMessageRequest = errors.New("conversation is not a pending message request")
+
+// ConversationFilter narrows the conversation list. Requests selects the
+// message requests inbox instead of the regular one; Archived selects the
+// conversations the user archived.
+type ConversationFilter struct {
+	Requests bool
+	Archived bool
+}
+
+// CreateDirectConversation starts a DM. With asRequest set it lands in the
+// recipient's message requests rather than their inbox.
+func (r *ChatRepository) CreateDirectConversation(ctx context.Context, senderID, recipientID string, asRequest bool) (*domain.Conversation, error) {
+	conv := &domain.Conversation{
+		ID:        uuid.New(),
+		Name:      "Direct Message",
+		IsGroup:   false,
+		CreatedAt: time.Now(),
+	}
+	if err := r.CreateConversation(ctx, conv, []string{senderID, recipientID}); err != nil {
+		return nil, err
+	}
+
+	if asRequest {
+		err := r.db.WithContext(ctx).Model(&domain.Participant{}).
+			Where("conversation_id = ? AND user_id = ?", conv.ID, recipientID).
+			Update("is_request", true).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return conv, nil
+}
+
+func pendingRequest(tx *gorm.DB, conversationID, userID string) error {
+	p, err := getParticipant(tx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if !p.IsRequest {
+		return ErrNoMessageRequest
+	}
+	return nil
+}
+
+// AcceptMessageRequest moves a conversation from the user's requests into
+// their inbox.
+func (r *ChatRepository) AcceptMessageRequest(ctx context.Context, conversationID, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := pendingRequest(tx, conversationID, userID); err != nil {
+			return err
+		}
+		return tx.Model(&domain.Participant{}).
+			Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+			Update("is_request", false).Error
+	})
+}
+
+// DeclineMessageRequest deletes a pending request along with what the sender
+// wrote so far. It returns the object keys of the deleted attachments.
+func (r *ChatRepository) DeclineMessageRequest(ctx context.Context, conversationID, userID string) ([]string, error) {
+	var objectKeys []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := pendingRequest(tx, conversationID, userID); err != nil {
+			return err
+		}
+		var err error
+		objectKeys, err = deleteConversation(tx, conversationID)
+		return err
+	})
+	return objectKeys, err
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultSearchPageSize = 20
+	MaxSearchPageSize     = 50
+)
+
+// MigrateSearch adds the full-text column and its GIN index to messages. The
+// column is generated by Postgres, so it never needs to be written by the
+// service. The 'simple' configuration is used because messages mix languages
+// and stemming for one of them would hurt the others.
+func MigrateSearch(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (to_tsvector('simple', coalesce(content, ''))) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING GIN (search_vector)`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchCursor is a keyset position in a ranked result list.
+type SearchCursor struct {
+	Rank      float32
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (c SearchCursor) Encode() string {
+	raw := fmt.Sprintf("%s:%d:%s", strconv.FormatFloat(float64(c.Rank), 'g', -1, 32), c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeSearchCursor(encoded string) (SearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	rank, err := strconv.ParseFloat(parts[0], 32)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return SearchCursor{}, ErrInvalidCursor
+	}
+
+	return SearchCursor{Rank: float32(rank), CreatedAt: time.Unix(0, nanos), ID: id}, nil
+}
+
+// SearchQuery describes a full-text search over the caller's conversations.
+// ConversationID optionally narrows it to one conversation. Messages from
+// HiddenSenderIDs are left out of group conversations.
+type SearchQuery struct {
+	UserID          string
+	Text            string
+	ConversationID  string
+	HiddenSenderIDs []string
+	Cursor          *SearchCursor
+	Limit           int
+}
+
+type SearchConversation struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	AvatarURL string    `json:"avatar_url"`
+	IsGroup   bool      `json:"is_group"`
+}
+
+// SearchHit is one matching message. Snippet is HTML-escaped content with
+// the matched terms wrapped in <mark>.
+type SearchHit struct {
+	MessageID    uuid.UUID          `json:"message_id"`
+	SenderID     uuid.UUID          `json:"sender_id"`
+	Content      string             `json:"content"`
+	MediaType    string             `json:"media_type"`
+	CreatedAt    time.Time          `json:"created_at"`
+	Snippet      string             `json:"snippet"`
+	Rank         float32            `json:"rank"`
+	Conversation SearchConversation `json:"conversation"`
+}
+
+type searchRow struct {
+	ID                    uuid.UUID
+	ConversationID        uuid.UUID
+	SenderID              uuid.UUID
+	Content               string
+	MediaType             string
+	CreatedAt             time.Time
+	Snippet               string
+	Rank                  float32
+	ConversationName      string
+	ConversationAvatarURL string
+	IsGroup               bool
+}
+
+// Content is escaped before ts_headline so the snippet can be rendered as
+// HTML without trusting what users typed.
+const searchSQL = `SELECT m.id, m.conversation_id, m.sender_id, m.content, m.media_type, m.created_at,
+	ts_rank(m.search_vector, q.query) AS rank,
+	ts_headline('simple',
+		replace(replace(replace(m.content, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+		q.query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=8, MaxFragments=2') AS snippet,
+	c.name AS conversation_name, c.avatar_url AS conversation_avatar_url, c.is_group
+FROM messages m
+JOIN participants p ON p.conversation_id = m.conversation_id AND p.user_id = ?
+JOIN conversations c ON c.id = m.conversation_id
+CROSS JOIN websearch_to_tsquery('simple', ?) AS q(query)
+WHERE m.search_vector @@ q.query
+	AND NOT m.is_unsent
+	AND m.media_type NOT IN ('system', 'call')
+	AND right(m.media_type, 6) <> '_share'
+	AND (p.hidden_at IS NULL OR m.created_at > p.hidden_at)
+	AND (m.expires_at IS NULL OR m.expires_at > now())`
+
+// SearchMessages runs a ranked full-text search across every conversation
+// the user takes part in. Results are ordered by rank, then newest first; the
+// returned cursor is empty on the last page.
+func (r *ChatRepository) SearchMessages(ctx context.Context, q SearchQuery) ([]SearchHit, string, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultSearchPageSize
+	}
+	if q.Limit > MaxSearchPageSize {
+		q.Limit = MaxSearchPageSize
+	}
+
+	var sql strings.Builder
+	sql.WriteString(searchSQL)
+	args := []interface{}{q.UserID, q.Text}
+
+	if q.ConversationID != "" {
+		sql.WriteString(" AND m.conversation_id = ?")
+		args = append(args, q.ConversationID)
+	}
+	if len(q.HiddenSenderIDs) > 0 {
+		sql.WriteString(" AND NOT (c.is_group AND m.sender_id IN ?)")
+		args = append(args, q.HiddenSenderIDs)
+	}
+	if q.Cursor != nil {
+		sql.WriteString(" AND (ts_rank(m.search_vector, q.query), m.created_at, m.id) < (?::real, ?, ?)")
+		args = append(args, q.Cursor.Rank, q.Cursor.CreatedAt, q.Cursor.ID)
+	}
+	sql.WriteString(" ORDER BY rank DESC, m.created_at DESC, m.id DESC LIMIT ?")
+	args = append(args, q.Limit+1)
+
+	var rows []searchRow
+	if err := r.db.WithContext(ctx).Raw(sql.String(), args...).Scan(&rows).Error; err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(rows) > q.Limit {
+		rows = rows[:q.Limit]
+		last := rows[len(rows)-1]
+		nextCursor = SearchCursor{Rank: last.Rank, CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	hits := make([]SearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = SearchHit{
+			MessageID: row.ID,
+			SenderID:  row.SenderID,
+			Content:   row.Content,
+			MediaType: row.MediaType,
+			CreatedAt: row.CreatedAt,
+			Snippet:   row.Snippet,
+			Rank:      row.Rank,
+			Conversation: SearchConversation{
+				ID:        row.ConversationID,
+				Name:      row.ConversationName,
+				AvatarURL: row.ConversationAvatarURL,
+				IsGroup:   row.IsGroup,
+			},
+		}
+	}
+	return hits, nextCursor, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// ParticipantSettings is a partial update of a participant's inbox settings.
+// Nil fields are left alone; a zero MutedUntil unmutes the conversation.
+type ParticipantSettings struct {
+	MutedUntil *time.Time
+	Pinned     *bool
+	Archived   *bool
+}
+
+// UpdateParticipantSettings changes how a conversation appears in the user's
+// inbox. Archiving a conversation also unpins it.
+func (r *ChatRepository) UpdateParticipantSettings(ctx context.Context, conversationID, userID string, settings ParticipantSettings) (*domain.Participant, error) {
+	var participant *domain.Participant
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		p, err := getParticipant(tx, conversationID, userID)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if settings.MutedUntil != nil {
+			if settings.MutedUntil.IsZero() {
+				updates["muted_until"] = nil
+			} else {
+				updates["muted_until"] = *settings.MutedUntil
+			}
+		}
+		if settings.Pinned != nil {
+			updates["is_pinned"] = *settings.Pinned
+		}
+		if settings.Archived != nil {
+			updates["is_archived"] = *settings.Archived
+			if *settings.Archived {
+				updates["is_pinned"] = false
+			}
+		}
+
+		if len(updates) > 0 {
+			err := tx.Model(&domain.Participant{}).
+				Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+				Updates(updates).Error
+			if err != nil {
+				return err
+			}
+			if p, err = getParticipant(tx, conversationID, userID); err != nil {
+				return err
+			}
+		}
+		participant = p
+		return nil
+	})
+	return participant, err
+}
+
+// MutedUserIDs returns the participants of a conversation who have it muted
+// at the given time.
+func (r *ChatRepository) MutedUserIDs(ctx context.Context, conversationID string, at time.Time) (map[string]bool, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Model(&domain.Participant{}).
+		Where("conversation_id = ? AND muted_until > ?", conversationID, at).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	muted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		muted[id] = true
+	}
+	return muted, nil
+}
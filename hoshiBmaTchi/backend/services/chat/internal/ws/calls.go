@@ -0,0 +1,162 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// signalTypes maps call states onto the signal_type clients already react
+// to. Every finished state is an "end" for them; the call carries the detail.
+var signalTypes = map[string]string{
+	domain.CallRinging:  "incoming",
+	domain.CallAccepted: "accepted",
+	domain.CallDeclined: "end",
+	domain.CallMissed:   "end",
+	domain.CallEnded:    "end",
+}
+
+// CallToken issues media credentials to a member of the conversation. Users
+// blocked from messaging each other cannot call either.
+func (h *Hub) CallToken(ctx context.Context, conversationID, userID string) (*calls.Credentials, error) {
+	if err := h.CanSend(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	return h.calls.Token(ctx, conversationID, userID)
+}
+
+// StartCall rings the conversation. A call left behind by an instance that
+// went away is finished first, so it cannot block the conversation.
+func (h *Hub) StartCall(ctx context.Context, conversationID, callerID, callType string) (*domain.Call, error) {
+	if err := h.CanSend(ctx, conversationID, callerID); err != nil {
+		return nil, err
+	}
+
+	if stale, err := h.calls.ActiveCall(ctx, conversationID); err == nil && stale != nil {
+		h.expireCall(stale.ID.String())
+	}
+
+	call, err := h.calls.Start(ctx, conversationID, callerID, callType)
+	if err != nil {
+		return nil, err
+	}
+	h.announceCall(ctx, call, callerID)
+
+	callID := call.ID.String()
+	time.AfterFunc(calls.RingTimeout, func() {
+		h.expireCall(callID)
+	})
+	return call, nil
+}
+
+// UpdateCall applies a member's answer or hang-up. callID may be empty to
+// mean the conversation's live call.
+func (h *Hub) UpdateCall(ctx context.Context, conversationID, callID, userID, signalType string) (*domain.Call, error) {
+	if callID == "" {
+		active, err := h.calls.ActiveCall(ctx, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		if active == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		callID = active.ID.String()
+	}
+
+	var (
+		call    *domain.Call
+		changed bool
+		err     error
+	)
+	switch signalType {
+	case "accept":
+		call, changed, err = h.calls.Accept(ctx, callID, userID)
+	case "decline":
+		call, changed, err = h.calls.Decline(ctx, callID, userID)
+	case "end":
+		call, changed, err = h.calls.End(ctx, callID, userID)
+	default:
+		return nil, errors.New("unknown signal type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		h.announceCall(ctx, call, userID)
+	}
+	return call, nil
+}
+
+// expireCall finishes a call that rang out or outlived MaxCallDuration. The
+// ring timer only fires on the instance that started the call, which is why
+// StartCall also sweeps a conversation's leftover call.
+func (h *Hub) expireCall(callID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	call, changed, err := h.calls.Expire(ctx, callID)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrInvalidCallTransition) {
+			log.Printf("Failed to expire call %s: %v", callID, err)
+		}
+		return
+	}
+	if changed {
+		h.announceCall(ctx, call, "")
+	}
+}
+
+// announceCall tells the conversation about a call's new state and, once the
+// call is over, adds its log entry to the history. actorID is the member who
+// caused the change, or empty when the server did, e.g. a call ringing out;
+// clients tell calls apart by call_id, not by sender.
+func (h *Hub) announceCall(ctx context.Context, call *domain.Call, actorID string) {
+	frame := WSMessage{
+		Type:           "signal",
+		SignalType:     signalTypes[call.Status],
+		CallType:       call.Type,
+		SenderID:       actorID,
+		ConversationID: call.ConversationID.String(),
+		CreatedAt:      time.Now(),
+		CallID:         call.ID.String(),
+		Call:           call,
+	}
+	if err := h.emit(ctx, frame.ConversationID, frame); err != nil {
+		log.Printf("Failed to announce call %s: %v", call.ID, err)
+	}
+
+	if !call.IsActive() {
+		if err := h.postCallLog(ctx, call); err != nil {
+			log.Printf("Failed to log call %s: %v", call.ID, err)
+		}
+	}
+}
+
+func (h *Hub) postCallLog(ctx context.Context, call *domain.Call) error {
+	content, err := json.Marshal(domain.NewCallLog(*call))
+	if err != nil {
+		return err
+	}
+
+	msg := &domain.Message{
+		ID:             uuid.New(),
+		ConversationID: call.ConversationID,
+		SenderID:       call.CallerID,
+		Content:        string(content),
+		MediaType:      domain.MediaTypeCall,
+		CreatedAt:      time.Now(),
+	}
+	if err := h.repo.SaveMessage(ctx, msg); err != nil {
+		return err
+	}
+	return h.PublishMessage(ctx, msg)
+}
@@ -3,11 +3,11 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"time"
 
-	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -17,15 +17,27 @@ const (
 	writeWait      = 10 * time.Second
 	pongWait       = 60 * time.Second
 	pingPeriod     = (pongWait * 9) / 10
-	maxMessageSize = 512
+
+	// maxMessageSize leaves room for a message of MaxContentLength
+	// multi-byte characters plus the rest of the frame.
+	maxMessageSize = 16 * 1024
 )
 
+// ErrMembershipUnavailable rejects a frame whose sender's membership could
+// not be checked.
+var ErrMembershipUnavailable = errors.New("can't check your membership right now, try again later")
+
 type Client struct {
+	ID     string
 	Hub    *Hub
 	Conn   *websocket.Conn
 	Send   chan []byte
 	UserID string
 	Repo   *repositories.ChatRepository
+
+	// tracked is closed once the hub has recorded the device, so removing
+	// it cannot overtake adding it.
+	tracked chan struct{}
 }
 
 func (c *Client) ReadPump() {
@@ -47,43 +59,38 @@ func (c *Client) ReadPump() {
 
 		var wsMsg WSMessage
 		if err := json.Unmarshal(message, &wsMsg); err != nil {
-			log.Printf("Error parsing message: %v", err)
+			c.sendError("", CodeInvalidFrame, "frame is not valid JSON")
 			continue
 		}
+		if problem := validateFrame(wsMsg); problem != "" {
+			c.sendError(wsMsg.ConversationID, CodeInvalidFrame, problem)
+			continue
+		}
+
+		ctx := context.Background()
 
-		if wsMsg.Type == "signal" {
-			wsMsg.SenderID = c.UserID
-			broadcastBytes, _ := json.Marshal(wsMsg)
-			c.Hub.Broadcast(broadcastBytes)
+		if wsMsg.Type == "resume" {
+			c.handleResume(ctx, wsMsg)
 			continue
 		}
 
-        mediaType := wsMsg.MediaType
-        if mediaType == "" {
-            mediaType = "text"
-        }
-
-		newID := uuid.New()
-        now := time.Now()
-
-		msg := &domain.Message{
-            ID:             newID,
-			ConversationID: uuid.MustParse(wsMsg.ConversationID),
-			SenderID:       uuid.MustParse(c.UserID),
-			Content:        wsMsg.Content,
-			MediaURL:       wsMsg.MediaURL,
-            MediaType:      mediaType, 
-			CreatedAt:      now,  
+		isMember, err := c.Hub.IsParticipant(ctx, wsMsg.ConversationID, c.UserID)
+		if err != nil {
+			log.Printf("Failed to check membership of user %s in %s: %v", c.UserID, wsMsg.ConversationID, err)
+			switch wsMsg.Type {
+			case "chat", "message", "":
+				c.rejectSend(wsMsg, ErrMembershipUnavailable)
+			default:
+				c.sendError(wsMsg.ConversationID, CodeUnavailable, ErrMembershipUnavailable.Error())
+			}
+			continue
 		}
-		
-		if err := c.Repo.SaveMessage(context.Background(), msg); err == nil {
-            wsMsg.Type = "new_message"
-            wsMsg.ID = newID.String()
-            wsMsg.CreatedAt = now
-            
-			broadcastBytes, _ := json.Marshal(wsMsg)
-			c.Hub.broadcast <- broadcastBytes
+		if !isMember {
+			c.sendError(wsMsg.ConversationID, CodeNotParticipant, "you are not a member of this conversation")
+			continue
 		}
+
+		c.handleFrame(ctx, wsMsg)
 	}
 }
 
@@ -111,11 +118,13 @@ func (c *Client) WritePump() {
 			if err := w.Close(); err != nil {
 				return
 			}
+			c.noteDelivered(message)
 		case <-ticker.C:
 			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
+			c.Hub.Heartbeat(context.Background(), c)
 		}
 	}
 }
@@ -125,7 +134,7 @@ func ServeWs(hub *Hub, repo *repositories.ChatRepository, w http.ResponseWriter,
     if err != nil {
         return
     }
-    client := &Client{Hub: hub, Conn: conn, Send: make(chan []byte, 256), UserID: userID, Repo: repo}
+    client := &Client{ID: uuid.NewString(), Hub: hub, Conn: conn, Send: make(chan []byte, 256), UserID: userID, Repo: repo}
     client.Hub.Register <- client
 
     go client.WritePump()
@@ -0,0 +1,106 @@
+package ws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// AckFrame confirms to the sending connection that a message was stored.
+// Duplicate is set when the message had already been sent under the same
+// client_msg_id; nothing new was posted then.
+type AckFrame struct {
+	Type           string    `json:"type"`
+	ClientMsgID    string    `json:"client_msg_id,omitempty"`
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	Duplicate      bool      `json:"duplicate,omitempty"`
+}
+
+func (c *Client) sendAck(msg *domain.Message, duplicate bool) {
+	frame := AckFrame{
+		Type:           "ack",
+		ID:             msg.ID.String(),
+		ConversationID: msg.ConversationID.String(),
+		CreatedAt:      msg.CreatedAt,
+		Duplicate:      duplicate,
+	}
+	if msg.ClientMsgID != nil {
+		frame.ClientMsgID = *msg.ClientMsgID
+	}
+	if msgBytes, err := json.Marshal(frame); err == nil {
+		select {
+		case c.Send <- msgBytes:
+		default:
+		}
+	}
+}
+
+// newMessageMarker lets WritePump skip decoding frames that cannot be new
+// messages. json.Marshal never puts spaces around the colon.
+var newMessageMarker = []byte(`"type":"new_message"`)
+
+// noteDelivered is called by WritePump after a frame was written to the
+// socket. For a new message from someone else it records the delivery.
+func (c *Client) noteDelivered(payload []byte) {
+	if !bytes.Contains(payload, newMessageMarker) {
+		return
+	}
+
+	var frame struct {
+		Type           string `json:"type"`
+		ID             string `json:"id"`
+		SenderID       string `json:"sender_id"`
+		ConversationID string `json:"conversation_id"`
+	}
+	if err := json.Unmarshal(payload, &frame); err != nil || frame.Type != "new_message" {
+		return
+	}
+	if frame.SenderID == c.UserID {
+		return
+	}
+	go c.Hub.markDelivered(frame.ConversationID, frame.ID, frame.SenderID, c.UserID)
+}
+
+// markDelivered stores the delivery and, the first time the recipient gets
+// the message on any device, tells the sender with a delivered frame.
+func (h *Hub) markDelivered(conversationID, messageID, senderID, recipientID string) {
+	msgID, err := uuid.Parse(messageID)
+	if err != nil {
+		return
+	}
+	userID, err := uuid.Parse(recipientID)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	first, err := h.repo.MarkDelivered(ctx, msgID, userID, now)
+	if err != nil {
+		log.Printf("Failed to record delivery of %s to %s: %v", messageID, recipientID, err)
+		return
+	}
+	if !first {
+		return
+	}
+
+	frame := map[string]interface{}{
+		"type":            "delivered",
+		"conversation_id": conversationID,
+		"message_id":      messageID,
+		"user_id":         recipientID,
+		"delivered_at":    now,
+	}
+	if msgBytes, err := json.Marshal(frame); err == nil {
+		h.SendToUsers([]string{senderID}, msgBytes)
+	}
+}
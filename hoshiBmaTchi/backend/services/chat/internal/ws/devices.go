@@ -0,0 +1,70 @@
+package ws
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+const (
+	devicesKeyPrefix = "chat:devices:"
+	devicesTTL       = 2 * pongWait
+)
+
+func devicesKey(userID string) string {
+	return devicesKeyPrefix + userID
+}
+
+// trackDevice records a connection in the user's Redis device set, keyed by
+// connection ID with the time it was last seen alive. The set is shared by
+// every chat instance, so counts cover all of a user's devices.
+func (h *Hub) trackDevice(ctx context.Context, client *Client) error {
+	key := devicesKey(client.UserID)
+
+	pipe := h.redis.TxPipeline()
+	pipe.HSet(ctx, key, client.ID, time.Now().Unix())
+	pipe.Expire(ctx, key, devicesTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (h *Hub) untrackDevice(ctx context.Context, client *Client) error {
+	return h.redis.HDel(ctx, devicesKey(client.UserID), client.ID).Err()
+}
+
+// refreshDevice marks the connection as alive. It is called from the ping
+// loop, so entries of connections lost without a clean close go stale.
+func (h *Hub) refreshDevice(ctx context.Context, client *Client) error {
+	return h.trackDevice(ctx, client)
+}
+
+// DeviceCount returns how many sockets the user currently has open across all
+// instances, pruning entries that stopped refreshing.
+func (h *Hub) DeviceCount(ctx context.Context, userID string) (int, error) {
+	key := devicesKey(userID)
+
+	devices, err := h.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-devicesTTL).Unix()
+	count := 0
+	for connID, seen := range devices {
+		ts, err := strconv.ParseInt(seen, 10, 64)
+		if err != nil || ts < cutoff {
+			h.redis.HDel(ctx, key, connID)
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (h *Hub) IsOnline(ctx context.Context, userID string) (bool, error) {
+	count, err := h.DeviceCount(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,206 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	// eventRetention is how far back a client can resume. One that was away
+	// longer is told to reset and reload its conversations instead.
+	eventRetention  = 14 * 24 * time.Hour
+	eventPruneBatch = 5000
+
+	DefaultSyncLimit = 200
+	MaxSyncLimit     = 500
+)
+
+// SyncEvent is one logged frame, as it was delivered live. Media URLs in it
+// may have expired since; clients refetch the message to display it.
+type SyncEvent struct {
+	Seq            int64           `json:"seq"`
+	Type           string          `json:"type"`
+	ConversationID string          `json:"conversation_id,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Frame          json.RawMessage `json:"frame"`
+}
+
+// SyncBatch answers a resume. Reset means events after the client's
+// sequence have been pruned, so it has to reload its conversations and
+// carry on from LatestSeq.
+type SyncBatch struct {
+	Type      string      `json:"type"`
+	Events    []SyncEvent `json:"events"`
+	LatestSeq int64       `json:"latest_seq"`
+	HasMore   bool        `json:"has_more"`
+	Reset     bool        `json:"reset,omitempty"`
+}
+
+// frameHeader picks out what the event log indexes a frame by.
+type frameHeader struct {
+	Type           string `json:"type"`
+	ID             string `json:"id"`
+	ConversationID string `json:"conversation_id"`
+}
+
+// PublishToUsers delivers a frame to the given users and records it in each
+// of their event logs, so clients that miss it can fetch it later. Every
+// copy carries the recipient's own seq. If the log cannot be written the
+// frame still goes out live, without a seq.
+func (h *Hub) PublishToUsers(ctx context.Context, userIDs []string, frame interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	var header frameHeader
+	json.Unmarshal(payload, &header)
+	rec := repositories.EventRecord{Type: header.Type, Payload: payload}
+	if id, err := uuid.Parse(header.ConversationID); err == nil {
+		rec.ConversationID = &id
+	}
+	// Message frames carry the message's ID in "id", so deleting the message
+	// can redact them.
+	if id, err := uuid.Parse(header.ID); err == nil {
+		rec.MessageID = &id
+	}
+
+	seqs, err := h.repo.AppendEvents(ctx, userIDs, rec)
+	if err != nil {
+		log.Printf("Failed to log %s event: %v", header.Type, err)
+		h.SendToUsers(userIDs, payload)
+		return nil
+	}
+	for _, userID := range userIDs {
+		seq, ok := seqs[userID]
+		if !ok {
+			continue
+		}
+		h.SendToUsers([]string{userID}, withSeq(payload, seq))
+	}
+	return nil
+}
+
+// PublishToConversation logs and delivers a frame to the conversation's
+// participants plus extraUserIDs, typically members who just left.
+func (h *Hub) PublishToConversation(ctx context.Context, conversationID string, frame interface{}, extraUserIDs ...string) error {
+	userIDs, err := h.participants.Get(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	return h.PublishToUsers(ctx, mergeUserIDs(userIDs, extraUserIDs), frame)
+}
+
+func mergeUserIDs(userIDs, extra []string) []string {
+	if len(extra) == 0 {
+		return userIDs
+	}
+	seen := make(map[string]bool, len(userIDs)+len(extra))
+	merged := make([]string, 0, len(userIDs)+len(extra))
+	for _, id := range append(append([]string{}, userIDs...), extra...) {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+// withSeq adds "seq" as the first field of a marshalled JSON object.
+func withSeq(payload []byte, seq int64) []byte {
+	out := make([]byte, 0, len(payload)+24)
+	out = append(out, `{"seq":`...)
+	out = strconv.AppendInt(out, seq, 10)
+	if len(payload) > 2 {
+		out = append(out, ',')
+	}
+	return append(out, payload[1:]...)
+}
+
+// Sync returns the user's events after seq, oldest first.
+func (h *Hub) Sync(ctx context.Context, userID string, seq int64, limit int) (*SyncBatch, error) {
+	if limit <= 0 || limit > MaxSyncLimit {
+		limit = DefaultSyncLimit
+	}
+
+	latest, err := h.repo.LatestEventSeq(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	batch := &SyncBatch{Type: "sync", Events: []SyncEvent{}, LatestSeq: latest}
+	if seq == latest {
+		return batch, nil
+	}
+	// A client ahead of the server knows of events that are gone, e.g. after
+	// a restore; it is as stale as one that fell behind retention.
+	if seq > latest {
+		batch.Reset = true
+		return batch, nil
+	}
+
+	logged, err := h.repo.EventsSince(ctx, userID, seq, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(logged) == 0 || logged[0].Seq != seq+1 {
+		batch.Reset = true
+		return batch, nil
+	}
+
+	for _, e := range logged {
+		event := SyncEvent{
+			Seq:       e.Seq,
+			Type:      e.Type,
+			CreatedAt: e.CreatedAt,
+			Frame:     json.RawMessage(e.Payload),
+		}
+		if e.ConversationID != nil {
+			event.ConversationID = e.ConversationID.String()
+		}
+		batch.Events = append(batch.Events, event)
+	}
+	batch.HasMore = logged[len(logged)-1].Seq < latest
+	return batch, nil
+}
+
+// pruneEvents drops events past retention. It runs under the sweeper lock.
+func (h *Hub) pruneEvents(ctx context.Context) {
+	before := time.Now().Add(-eventRetention)
+	for i := 0; i < expiryMaxBatches; i++ {
+		n, err := h.repo.PruneEvents(ctx, before, eventPruneBatch)
+		if err != nil {
+			log.Printf("Failed to prune event log: %v", err)
+			return
+		}
+		if n < eventPruneBatch {
+			return
+		}
+	}
+}
+
+// handleResume answers a resume frame with the events this connection
+// missed. Clients page by resuming again from the last seq while has_more
+// is set.
+func (c *Client) handleResume(ctx context.Context, wsMsg WSMessage) {
+	batch, err := c.Hub.Sync(ctx, c.UserID, wsMsg.Seq, wsMsg.Limit)
+	if err != nil {
+		log.Printf("Failed to sync user %s from %d: %v", c.UserID, wsMsg.Seq, err)
+		c.sendError("", CodeInternal, "could not load missed events")
+		return
+	}
+	if msgBytes, err := json.Marshal(batch); err == nil {
+		select {
+		case c.Send <- msgBytes:
+		default:
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package ws
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	expirySweepInterval = time.Minute
+	expiryBatchSize     = 500
+
+	// expiryMaxBatches caps one sweep so it finishes well within the lock
+	// TTL; whatever is left waits for the next tick.
+	expiryMaxBatches = 20
+
+	sweeperLockKey = "chat:lock:expiry_sweeper"
+	sweeperLockTTL = 2 * time.Minute
+)
+
+// releaseLockScript deletes the lock only if this instance still holds it,
+// so a sweep that overran its TTL cannot release someone else's lock.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// RunExpirySweeper deletes disappearing messages once they expire, and
+// flushes notifications whose timers were lost. Every instance runs the
+// loop, but a Redis lock lets only one of them sweep at a time.
+func (h *Hub) RunExpirySweeper(ctx context.Context) {
+	ticker := time.NewTicker(expirySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.sweepExpired(ctx)
+		}
+	}
+}
+
+func (h *Hub) sweepExpired(ctx context.Context) {
+	token := uuid.NewString()
+	acquired, err := h.redis.SetNX(ctx, sweeperLockKey, token, sweeperLockTTL).Result()
+	if err != nil {
+		log.Printf("Failed to acquire expiry sweeper lock: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+	defer releaseLockScript.Run(ctx, h.redis, []string{sweeperLockKey}, token)
+
+	for i := 0; i < expiryMaxBatches; i++ {
+		n, err := h.sweepBatch(ctx)
+		if err != nil {
+			log.Printf("Expiry sweep failed: %v", err)
+			break
+		}
+		if n < expiryBatchSize {
+			break
+		}
+	}
+	h.pruneEvents(ctx)
+	h.flushDueNotifications(ctx)
+}
+
+// RemoveObjects removes the stored files of attachments whose rows were
+// already deleted, by the sweeper or along with a conversation. Failures are
+// only logged: nothing refers to the objects any more.
+func (h *Hub) RemoveObjects(ctx context.Context, objectKeys []string) {
+	for _, key := range objectKeys {
+		if err := h.media.Delete(ctx, key); err != nil {
+			log.Printf("Failed to remove attachment object %s: %v", key, err)
+		}
+	}
+}
+
+// sweepBatch deletes one batch of expired messages, and then the objects of
+// their attachments.
+func (h *Hub) sweepBatch(ctx context.Context) (int, error) {
+	expired, err := h.repo.ExpiredMessages(ctx, time.Now(), expiryBatchSize)
+	if err != nil || len(expired) == 0 {
+		return 0, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(expired))
+	byConversation := make(map[string][]string)
+	for _, m := range expired {
+		ids = append(ids, m.ID)
+		convID := m.ConversationID.String()
+		byConversation[convID] = append(byConversation[convID], m.ID.String())
+	}
+
+	objectKeys, err := h.repo.DeleteMessages(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+	h.RemoveObjects(ctx, objectKeys)
+
+	for convID, messageIDs := range byConversation {
+		frame := map[string]interface{}{
+			"type":            "messages_expired",
+			"conversation_id": convID,
+			"message_ids":     messageIDs,
+		}
+		if err := h.PublishToConversation(ctx, convID, frame); err != nil {
+			log.Printf("Failed to announce expired messages in %s: %v", convID, err)
+		}
+	}
+	return len(expired), nil
+}
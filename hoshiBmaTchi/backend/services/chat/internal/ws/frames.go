@@ -0,0 +1,222 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// handleFrame dispatches a frame from a client whose membership in the
+// frame's conversation has already been checked.
+func (c *Client) handleFrame(ctx context.Context, wsMsg WSMessage) {
+	switch wsMsg.Type {
+	case "read":
+		if _, err := c.Hub.MarkRead(ctx, wsMsg.ConversationID, c.UserID, wsMsg.ID); err != nil {
+			log.Printf("Failed to mark conversation %s as read: %v", wsMsg.ConversationID, err)
+		}
+
+	// Typing frames are ephemeral: they are relayed to the conversation
+	// and never stored.
+	case "typing_start", "typing_stop":
+		frame := WSMessage{
+			Type:           wsMsg.Type,
+			SenderID:       c.UserID,
+			ConversationID: wsMsg.ConversationID,
+			CreatedAt:      time.Now(),
+		}
+		broadcastBytes, _ := json.Marshal(frame)
+		c.Hub.SendToConversation(ctx, wsMsg.ConversationID, broadcastBytes)
+
+	case "signal":
+		c.handleSignal(ctx, wsMsg)
+
+	case "reaction":
+		if _, err := c.Hub.React(ctx, wsMsg.ID, c.UserID, wsMsg.Emoji); err != nil {
+			log.Printf("Failed to react to message %s: %v", wsMsg.ID, err)
+		}
+
+	case "reaction_remove":
+		if _, err := c.Hub.Unreact(ctx, wsMsg.ID, c.UserID); err != nil {
+			log.Printf("Failed to remove reaction from message %s: %v", wsMsg.ID, err)
+		}
+
+	case "edit":
+		if _, err := c.Hub.EditMessage(ctx, wsMsg.ID, c.UserID, wsMsg.Content); err != nil {
+			log.Printf("Failed to edit message %s: %v", wsMsg.ID, err)
+		}
+
+	case "chat", "message", "":
+		c.handleChatMessage(ctx, wsMsg)
+	}
+}
+
+// sendError tells this connection that its frame was rejected.
+func (c *Client) sendError(conversationID, code, message string) {
+	c.sendErrorFrame(ErrorFrame{ConversationID: conversationID, Code: code, Message: message})
+}
+
+func (c *Client) sendErrorFrame(frame ErrorFrame) {
+	frame.Type = "error"
+	if msgBytes, err := json.Marshal(frame); err == nil {
+		select {
+		case c.Send <- msgBytes:
+		default:
+		}
+	}
+}
+
+// rejectSend answers a message that was not sent with an error frame
+// carrying its client_msg_id, so the client knows the send failed and why.
+func (c *Client) rejectSend(wsMsg WSMessage, err error) {
+	frame := ErrorFrame{
+		ConversationID: wsMsg.ConversationID,
+		ClientMsgID:    wsMsg.ClientMsgID,
+		Message:        err.Error(),
+	}
+
+	var rateLimited *RateLimitError
+	switch {
+	case errors.As(err, &rateLimited):
+		frame.Code = CodeRateLimited
+		frame.RetryAfterMs = rateLimited.RetryAfter.Milliseconds()
+	case errors.Is(err, ErrSpam):
+		frame.Code = CodeSpam
+	case errors.Is(err, ErrBlocked):
+		frame.Code = CodeBlocked
+	case errors.Is(err, ErrBlockCheckUnavailable), errors.Is(err, ErrMembershipUnavailable):
+		frame.Code = CodeUnavailable
+	case errors.Is(err, repositories.ErrInvalidAttachment):
+		frame.Code = CodeInvalidAttachment
+	case errors.Is(err, repositories.ErrInvalidReplyTarget):
+		frame.Code = CodeInvalidFrame
+	default:
+		log.Printf("Failed to send message from user %s: %v", c.UserID, err)
+		frame.Code = CodeInternal
+		frame.Message = "message could not be sent"
+	}
+	c.sendErrorFrame(frame)
+}
+
+// handleSignal drives the call state machine. The resulting state is
+// broadcast by the hub, so nothing is relayed from here.
+func (c *Client) handleSignal(ctx context.Context, wsMsg WSMessage) {
+	var err error
+	switch wsMsg.SignalType {
+	case "incoming", "start":
+		_, err = c.Hub.StartCall(ctx, wsMsg.ConversationID, c.UserID, wsMsg.CallType)
+	case "accept", "decline", "end":
+		_, err = c.Hub.UpdateCall(ctx, wsMsg.ConversationID, wsMsg.CallID, c.UserID, wsMsg.SignalType)
+	default:
+		c.sendError(wsMsg.ConversationID, CodeInvalidSignal, "unknown signal type")
+		return
+	}
+
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrBlocked):
+		c.sendError(wsMsg.ConversationID, CodeBlocked, "call is not available")
+	case errors.Is(err, ErrBlockCheckUnavailable):
+		c.sendError(wsMsg.ConversationID, CodeUnavailable, err.Error())
+	case errors.Is(err, repositories.ErrCallInProgress):
+		c.sendError(wsMsg.ConversationID, CodeCallBusy, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.sendError(wsMsg.ConversationID, CodeCallNotFound, "call not found")
+	case errors.Is(err, repositories.ErrInvalidCallTransition),
+		errors.Is(err, calls.ErrInvalidCallType),
+		errors.Is(err, calls.ErrCallerAction):
+		c.sendError(wsMsg.ConversationID, CodeInvalidCallState, err.Error())
+	default:
+		log.Printf("Failed to handle %s signal from user %s: %v", wsMsg.SignalType, c.UserID, err)
+	}
+}
+
+// handleChatMessage stores and delivers a message and acks it to the
+// sending connection. The frame has already passed validateFrame, so its IDs
+// parse.
+func (c *Client) handleChatMessage(ctx context.Context, wsMsg WSMessage) {
+	senderID, err := uuid.Parse(c.UserID)
+	if err != nil {
+		c.rejectSend(wsMsg, err)
+		return
+	}
+
+	// A resend of a message that already went through is acked again before
+	// any limits apply, so retries never count twice.
+	if wsMsg.ClientMsgID != "" {
+		existing, err := c.Repo.MessageByClientMsgID(ctx, senderID, wsMsg.ClientMsgID)
+		if err != nil {
+			c.rejectSend(wsMsg, err)
+			return
+		}
+		if existing != nil {
+			c.sendAck(existing, true)
+			return
+		}
+	}
+
+	if err := c.Hub.CanSend(ctx, wsMsg.ConversationID, c.UserID); err != nil {
+		c.rejectSend(wsMsg, err)
+		return
+	}
+	if err := c.Hub.CheckSendRate(ctx, wsMsg.ConversationID, c.UserID, wsMsg.Content); err != nil {
+		c.rejectSend(wsMsg, err)
+		return
+	}
+
+	conversationID, _ := uuid.Parse(wsMsg.ConversationID)
+
+	mediaType := wsMsg.MediaType
+	if mediaType == "" {
+		mediaType = "text"
+	}
+
+	msg := &domain.Message{
+		ID:             uuid.New(),
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Content:        wsMsg.Content,
+		MediaURL:       wsMsg.MediaURL,
+		MediaType:      mediaType,
+		CreatedAt:      time.Now(),
+	}
+
+	if wsMsg.ReplyToID != "" {
+		replyToID, _ := uuid.Parse(wsMsg.ReplyToID)
+		msg.ReplyToID = &replyToID
+	}
+
+	if wsMsg.AttachmentID != "" {
+		attachmentID, _ := uuid.Parse(wsMsg.AttachmentID)
+		msg.AttachmentID = &attachmentID
+	}
+
+	if wsMsg.ClientMsgID != "" {
+		clientMsgID := wsMsg.ClientMsgID
+		msg.ClientMsgID = &clientMsgID
+	}
+
+	err = c.Repo.SaveMessage(ctx, msg)
+	switch {
+	case errors.Is(err, repositories.ErrDuplicateMessage):
+		c.sendAck(msg, true)
+		return
+	case err != nil:
+		c.rejectSend(wsMsg, err)
+		return
+	}
+
+	// The message is stored either way, so it is acked even when the live
+	// fan-out fails; the other members get it from history.
+	if err := c.Hub.PublishMessage(ctx, msg); err != nil {
+		log.Printf("Failed to publish message %s: %v", msg.ID, err)
+	}
+	c.sendAck(msg, false)
+}
@@ -0,0 +1,43 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/google/uuid"
+)
+
+// PostSystemEvent records a change to the conversation, such as a member
+// joining or the group being renamed, as a system message in its history and
+// delivers it like any other message. The actor is stored as the sender.
+func (h *Hub) PostSystemEvent(ctx context.Context, conversationID string, event domain.SystemEvent) (*domain.Message, error) {
+	convID, err := uuid.Parse(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	actorID, err := uuid.Parse(event.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &domain.Message{
+		ID:             uuid.New(),
+		ConversationID: convID,
+		SenderID:       actorID,
+		Content:        string(content),
+		MediaType:      domain.MediaTypeSystem,
+		CreatedAt:      time.Now(),
+	}
+	if err := h.repo.SaveMessage(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, h.PublishMessage(ctx, msg)
+}
@@ -2,52 +2,129 @@ package ws
 
 import (
 	"context"
+	"encoding/json"
+	"log"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/calls"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/clients"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/media"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/ratelimit"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	userChannelPrefix          = "chat:user:"
+	participantsChangedChannel = "chat:participants_changed"
+
+	// redisTimeout bounds the Redis calls made for the hub loop, so a slow
+	// Redis cannot stall delivery to every socket on the instance.
+	redisTimeout = 3 * time.Second
+)
+
 type WSMessage struct {
-	Type           string `json:"type"`
-	ID             string    `json:"id,omitempty"`         
+	Type           string    `json:"type"`
+	ID             string    `json:"id,omitempty"`
 	CreatedAt      time.Time `json:"created_at,omitempty"`
-	SenderID       string `json:"sender_id"`
-	ConversationID string `json:"conversation_id"`
-	Content        string `json:"content"`
-	MediaURL       string `json:"media_url,omitempty"`
-	MediaType      string `json:"media_type,omitempty"`
+	SenderID       string    `json:"sender_id"`
+	ConversationID string    `json:"conversation_id"`
+	Content        string    `json:"content"`
+	MediaURL       string    `json:"media_url,omitempty"`
+	MediaType      string    `json:"media_type,omitempty"`
 
 	SignalType string `json:"signal_type,omitempty"`
 	CallType   string `json:"call_type,omitempty"`
+
+	ReplyToID string     `json:"reply_to_id,omitempty"`
+	Emoji     string     `json:"emoji,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+
+	AttachmentID string             `json:"attachment_id,omitempty"`
+	Attachment   *domain.Attachment `json:"attachment,omitempty"`
+
+	CallID string       `json:"call_id,omitempty"`
+	Call   *domain.Call `json:"call,omitempty"`
+
+	// Silent tells the client not to alert for the message because the
+	// recipient muted the conversation.
+	Silent bool `json:"silent,omitempty"`
+
+	// ClientMsgID is the sender's own ID for a message, echoed back so the
+	// sender's devices can match it to what they sent.
+	ClientMsgID string `json:"client_msg_id,omitempty"`
+
+	// Seq and Limit are only read from resume frames: the last event
+	// sequence the client has seen and how many events it wants back.
+	Seq   int64 `json:"seq,omitempty"`
+	Limit int   `json:"limit,omitempty"`
+}
+
+type delivery struct {
+	userIDs []string
+	payload []byte
 }
 
 type Hub struct {
-	clients    map[string]*Client
-	Register   chan *Client
-	unregister chan *Client
-	broadcast  chan []byte
-	redis      *redis.Client
-	mu         sync.Mutex
+	// clients holds every local connection of a user, keyed by connection ID,
+	// so several tabs or phones can be online at once.
+	clients      map[string]map[string]*Client
+	Register     chan *Client
+	unregister   chan *Client
+	deliver      chan delivery
+	redis        *redis.Client
+	pubsub       *redis.PubSub
+	repo         *repositories.ChatRepository
+	participants *participantCache
+	users        *clients.UserServiceClient
+	media        *media.Store
+	events       *events.Publisher
+	calls        *calls.Service
+	limiter      *ratelimit.Limiter
+	mu           sync.Mutex
 }
 
-func NewHub(rdb *redis.Client) *Hub {
+func NewHub(rdb *redis.Client, repo *repositories.ChatRepository, users *clients.UserServiceClient, store *media.Store, publisher *events.Publisher, callService *calls.Service) *Hub {
 	return &Hub{
-		clients:    make(map[string]*Client),
-		Register:   make(chan *Client),
-		unregister: make(chan *Client),
-		broadcast:  make(chan []byte),
-		redis:      rdb,
+		clients:      make(map[string]map[string]*Client),
+		Register:     make(chan *Client),
+		unregister:   make(chan *Client),
+		deliver:      make(chan delivery, 256),
+		redis:        rdb,
+		repo:         repo,
+		participants: newParticipantCache(repo),
+		users:        users,
+		media:        store,
+		events:       publisher,
+		calls:        callService,
+		limiter:      ratelimit.NewLimiter(rdb),
 	}
 }
 
+func userChannel(userID string) string {
+	return userChannelPrefix + userID
+}
+
 func (h *Hub) Run() {
-	pubsub := h.redis.Subscribe(context.Background(), "chat_broadcast")
-	ch := pubsub.Channel()
+	ctx := context.Background()
+
+	// Each instance only listens on the channels of users connected to it, so
+	// a frame reaches the instances that actually hold a member's socket.
+	h.pubsub = h.redis.Subscribe(ctx, participantsChangedChannel)
+	ch := h.pubsub.Channel()
 
 	go func() {
 		for msg := range ch {
-			h.sendToLocalClients([]byte(msg.Payload))
+			if msg.Channel == participantsChangedChannel {
+				h.participants.Invalidate(msg.Payload)
+				continue
+			}
+			userID := strings.TrimPrefix(msg.Channel, userChannelPrefix)
+			h.sendToLocalClients(userID, []byte(msg.Payload))
 		}
 	}()
 
@@ -55,37 +132,323 @@ func (h *Hub) Run() {
 		select {
 		case client := <-h.Register:
 			h.mu.Lock()
-			h.clients[client.UserID] = client
+			conns, ok := h.clients[client.UserID]
+			if !ok {
+				conns = make(map[string]*Client)
+				h.clients[client.UserID] = conns
+			}
+			conns[client.ID] = client
+			firstLocal := len(conns) == 1
 			h.mu.Unlock()
 
+			if firstLocal {
+				h.subscribe(client.UserID)
+			}
+			client.tracked = make(chan struct{})
+			go h.connected(client)
+
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if _, ok := h.clients[client.UserID]; ok {
-				delete(h.clients, client.UserID)
-				close(client.Send)
+			lastLocal := false
+			if conns, ok := h.clients[client.UserID]; ok {
+				if _, ok := conns[client.ID]; ok {
+					delete(conns, client.ID)
+					close(client.Send)
+				}
+				if len(conns) == 0 {
+					delete(h.clients, client.UserID)
+					lastLocal = true
+				}
 			}
 			h.mu.Unlock()
 
-		case message := <-h.broadcast:
-			h.redis.Publish(context.Background(), "chat_broadcast", message)
+			if client.tracked != nil {
+				go h.disconnected(client)
+			}
+			if lastLocal {
+				h.unsubscribe(client.UserID)
+			}
+
+		case d := <-h.deliver:
+			pubCtx, cancel := context.WithTimeout(ctx, redisTimeout)
+			for _, userID := range d.userIDs {
+				if err := h.redis.Publish(pubCtx, userChannel(userID), d.payload).Err(); err != nil {
+					log.Printf("Failed to publish to user %s: %v", userID, err)
+				}
+			}
+			cancel()
 		}
 	}
 }
 
-func (h *Hub) sendToLocalClients(msg []byte) {
+// subscribe and unsubscribe stay on the hub loop, so a user's channel is
+// never left subscribed by a disconnect racing a reconnect.
+func (h *Hub) subscribe(userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := h.pubsub.Subscribe(ctx, userChannel(userID)); err != nil {
+		log.Printf("Failed to subscribe to channel for user %s: %v", userID, err)
+	}
+}
+
+func (h *Hub) unsubscribe(userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := h.pubsub.Unsubscribe(ctx, userChannel(userID)); err != nil {
+		log.Printf("Failed to unsubscribe channel for user %s: %v", userID, err)
+	}
+}
+
+// connected records a new socket in the device set and announces the user
+// online if it is their first device anywhere. It runs off the hub loop and
+// closes client.tracked when done.
+func (h *Hub) connected(client *Client) {
+	defer close(client.tracked)
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := h.trackDevice(ctx, client); err != nil {
+		log.Printf("Failed to track device %s for user %s: %v", client.ID, client.UserID, err)
+	}
+	h.touchLastSeen(ctx, client.UserID)
+	if devices, err := h.DeviceCount(ctx, client.UserID); err == nil && devices == 1 {
+		go h.announcePresence(context.Background(), client.UserID, true)
+	}
+}
+
+// disconnected undoes connected, after it has finished, and announces the
+// user offline once their last device is gone.
+func (h *Hub) disconnected(client *Client) {
+	<-client.tracked
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	if err := h.untrackDevice(ctx, client); err != nil {
+		log.Printf("Failed to untrack device %s for user %s: %v", client.ID, client.UserID, err)
+	}
+	h.touchLastSeen(ctx, client.UserID)
+	if devices, err := h.DeviceCount(ctx, client.UserID); err == nil && devices == 0 {
+		go h.announcePresence(context.Background(), client.UserID, false)
+	}
+}
+
+func (h *Hub) sendToLocalClients(userID string, msg []byte) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	
-	for _, client := range h.clients {
+
+	for _, client := range h.clients[userID] {
 		select {
 		case client.Send <- msg:
 		default:
-			close(client.Send)
-			delete(h.clients, client.UserID)
+			// A stalled device must not hold up the user's other devices.
+			// Closing the socket makes ReadPump unregister it.
+			client.Conn.Close()
+		}
+	}
+}
+
+// SendToUsers delivers a frame to every socket of the given users, whichever
+// instance they are connected to.
+func (h *Hub) SendToUsers(userIDs []string, message []byte) {
+	if len(userIDs) == 0 {
+		return
+	}
+	h.deliver <- delivery{userIDs: userIDs, payload: message}
+}
+
+// SendToConversation delivers a frame to the current participants of a
+// conversation.
+func (h *Hub) SendToConversation(ctx context.Context, conversationID string, message []byte) error {
+	userIDs, err := h.participants.Get(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	h.SendToUsers(userIDs, message)
+	return nil
+}
+
+// PublishMessage fans a persisted message out to its conversation as a
+// new_message frame. Members with a block against the sender are skipped,
+// and members who muted the conversation get the frame marked silent.
+// Everyone else who is offline gets a push notification.
+func (h *Hub) PublishMessage(ctx context.Context, msg *domain.Message) error {
+	h.media.SignMessage(ctx, msg)
+
+	frame := WSMessage{
+		Type:           "new_message",
+		ID:             msg.ID.String(),
+		CreatedAt:      msg.CreatedAt,
+		SenderID:       msg.SenderID.String(),
+		ConversationID: msg.ConversationID.String(),
+		Content:        msg.Content,
+		MediaURL:       msg.MediaURL,
+		MediaType:      msg.MediaType,
+	}
+	if msg.ReplyToID != nil {
+		frame.ReplyToID = msg.ReplyToID.String()
+	}
+	if msg.ClientMsgID != nil {
+		frame.ClientMsgID = *msg.ClientMsgID
+	}
+	if msg.Attachment != nil {
+		frame.AttachmentID = msg.Attachment.ID.String()
+		frame.Attachment = msg.Attachment
+	}
+
+	userIDs, err := h.participants.Get(ctx, frame.ConversationID)
+	if err != nil {
+		return err
+	}
+	blocked := h.blockedUserIDs(ctx, frame.SenderID)
+	muted := h.mutedUserIDs(ctx, frame.ConversationID)
+
+	recipients := make([]string, 0, len(userIDs))
+	var silenced []string
+	for _, id := range userIDs {
+		switch {
+		case blocked[id]:
+		case muted[id] && id != frame.SenderID:
+			silenced = append(silenced, id)
+		default:
+			recipients = append(recipients, id)
 		}
 	}
+
+	if err := h.PublishToUsers(ctx, recipients, frame); err != nil {
+		return err
+	}
+	go h.queueOfflineNotifications(msg, recipients)
+	if len(silenced) > 0 {
+		frame.Silent = true
+		return h.PublishToUsers(ctx, silenced, frame)
+	}
+	return nil
 }
 
-func (h *Hub) Broadcast(message []byte) {
-	h.broadcast <- message
-}
\ No newline at end of file
+// mutedUserIDs returns who muted the conversation. A failed lookup is logged
+// and treated as nobody muted: an extra alert beats a lost message.
+func (h *Hub) mutedUserIDs(ctx context.Context, conversationID string) map[string]bool {
+	muted, err := h.repo.MutedUserIDs(ctx, conversationID, time.Now())
+	if err != nil {
+		log.Printf("Failed to load muted participants of %s: %v", conversationID, err)
+		return nil
+	}
+	return muted
+}
+
+// emit sends a frame to the conversation without logging it, for state that
+// is stale by the time a client reconnects.
+func (h *Hub) emit(ctx context.Context, conversationID string, frame interface{}) error {
+	msgBytes, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	return h.SendToConversation(ctx, conversationID, msgBytes)
+}
+
+// React stores the user's reaction and emits reaction_added.
+func (h *Hub) React(ctx context.Context, messageID, userID, emoji string) (*domain.Message, error) {
+	msg, err := h.repo.AddReaction(ctx, messageID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := WSMessage{
+		Type:           "reaction_added",
+		ID:             msg.ID.String(),
+		ConversationID: msg.ConversationID.String(),
+		SenderID:       userID,
+		Emoji:          strings.TrimSpace(emoji),
+		CreatedAt:      time.Now(),
+	}
+	return msg, h.PublishToConversation(ctx, frame.ConversationID, frame)
+}
+
+func (h *Hub) Unreact(ctx context.Context, messageID, userID string) (*domain.Message, error) {
+	msg, err := h.repo.RemoveReaction(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := WSMessage{
+		Type:           "reaction_removed",
+		ID:             msg.ID.String(),
+		ConversationID: msg.ConversationID.String(),
+		SenderID:       userID,
+		CreatedAt:      time.Now(),
+	}
+	return msg, h.PublishToConversation(ctx, frame.ConversationID, frame)
+}
+
+// EditMessage applies an edit through the repository rules and emits
+// message_edited with the new content.
+func (h *Hub) EditMessage(ctx context.Context, messageID, userID, content string) (*domain.Message, error) {
+	msg, err := h.repo.EditMessage(ctx, messageID, userID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := WSMessage{
+		Type:           "message_edited",
+		ID:             msg.ID.String(),
+		ConversationID: msg.ConversationID.String(),
+		SenderID:       userID,
+		Content:        msg.Content,
+		CreatedAt:      msg.CreatedAt,
+		EditedAt:       msg.EditedAt,
+	}
+	return msg, h.PublishToConversation(ctx, frame.ConversationID, frame)
+}
+
+// MarkRead moves the user's read pointer and tells the conversation about
+// it. An empty messageID marks everything up to the latest message as read.
+// The receipt also reaches the reader's other devices so they can clear
+// their unread badge.
+func (h *Hub) MarkRead(ctx context.Context, conversationID, userID, messageID string) (string, error) {
+	if messageID == "" {
+		latest, err := h.repo.GetLatestMessage(ctx, conversationID)
+		if err != nil {
+			return "", err
+		}
+		messageID = latest.ID.String()
+	}
+
+	if err := h.repo.MarkConversationAsRead(ctx, conversationID, userID, messageID); err != nil {
+		return "", err
+	}
+
+	frame := map[string]interface{}{
+		"type":            "read_receipt",
+		"conversation_id": conversationID,
+		"user_id":         userID,
+		"message_id":      messageID,
+		"read_at":         time.Now(),
+	}
+	msgBytes, err := json.Marshal(frame)
+	if err != nil {
+		return messageID, err
+	}
+	return messageID, h.SendToConversation(ctx, conversationID, msgBytes)
+}
+
+func (h *Hub) IsParticipant(ctx context.Context, conversationID, userID string) (bool, error) {
+	userIDs, err := h.participants.Get(ctx, conversationID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range userIDs {
+		if id == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// InvalidateParticipants drops the cached member list of a conversation on
+// every instance. Call it after adding or removing participants.
+func (h *Hub) InvalidateParticipants(conversationID string) {
+	h.participants.Invalidate(conversationID)
+	if err := h.redis.Publish(context.Background(), participantsChangedChannel, conversationID).Err(); err != nil {
+		log.Printf("Failed to publish participant invalidation for %s: %v", conversationID, err)
+	}
+}
@@ -0,0 +1,123 @@
+package ws
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrSpam = errors.New("message was flagged as spam")
+
+// RateLimitError is returned when a sender has used up their budget.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("sending too fast, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+var (
+	// userSendLimit applies to everything one user sends, in any
+	// conversation.
+	userSendLimit = ratelimit.PerMinute(30, 10)
+
+	// conversationSendLimit applies to all members of a conversation
+	// together, so a group cannot be flooded by several accounts at once.
+	conversationSendLimit = ratelimit.PerMinute(120, 30)
+)
+
+const (
+	rateLimitPrefix = "chat:ratelimit:"
+	spamPrefix      = "chat:spam:"
+
+	// The same text going to more than spamMaxConversations conversations
+	// within spamWindow is treated as spam. Short texts like "hi" or "ok"
+	// are exempt since people legitimately send them everywhere.
+	spamWindow           = 10 * time.Minute
+	spamMaxConversations = 5
+	spamMinLength        = 20
+
+	// Repeating the same text in one conversation more than
+	// spamMaxRepeats times within spamRepeatWindow is spam too.
+	spamRepeatWindow = time.Minute
+	spamMaxRepeats   = 5
+)
+
+// CheckSendRate decides whether a user may send content to a conversation
+// right now. It returns a *RateLimitError or ErrSpam when not. Like the
+// block checks, it lets messages through if Redis cannot be reached.
+func (h *Hub) CheckSendRate(ctx context.Context, conversationID, senderID, content string) error {
+	buckets := []struct {
+		key   string
+		limit ratelimit.Limit
+	}{
+		{rateLimitPrefix + "user:" + senderID, userSendLimit},
+		{rateLimitPrefix + "conversation:" + conversationID, conversationSendLimit},
+	}
+	for _, b := range buckets {
+		res, err := h.limiter.Allow(ctx, b.key, b.limit)
+		if err != nil {
+			log.Printf("Failed to check send rate of user %s: %v", senderID, err)
+			return nil
+		}
+		if !res.Allowed {
+			return &RateLimitError{RetryAfter: res.RetryAfter}
+		}
+	}
+
+	return h.checkSpam(ctx, conversationID, senderID, content)
+}
+
+// spamFingerprint identifies a text regardless of case and spacing. It
+// returns "" for blank text, and fanOut reports whether the text is long
+// enough to count towards the cross-conversation limit.
+func spamFingerprint(content string) (hash string, fanOut bool) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(content)), " ")
+	if normalized == "" {
+		return "", false
+	}
+	sum := sha1.Sum([]byte(normalized))
+	return hex.EncodeToString(sum[:]), utf8.RuneCountInString(normalized) >= spamMinLength
+}
+
+func (h *Hub) checkSpam(ctx context.Context, conversationID, senderID, content string) error {
+	hash, fanOut := spamFingerprint(content)
+	if hash == "" {
+		return nil
+	}
+
+	repeatKey := spamPrefix + "repeat:" + senderID + ":" + conversationID + ":" + hash
+	fanOutKey := spamPrefix + "fanout:" + senderID + ":" + hash
+
+	pipe := h.redis.TxPipeline()
+	repeats := pipe.Incr(ctx, repeatKey)
+	pipe.Expire(ctx, repeatKey, spamRepeatWindow)
+	var conversations *redis.IntCmd
+	if fanOut {
+		pipe.SAdd(ctx, fanOutKey, conversationID)
+		pipe.Expire(ctx, fanOutKey, spamWindow)
+		conversations = pipe.SCard(ctx, fanOutKey)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to run spam checks for user %s: %v", senderID, err)
+		return nil
+	}
+
+	if repeats.Val() > spamMaxRepeats {
+		return ErrSpam
+	}
+	if conversations != nil && conversations.Val() > spamMaxConversations {
+		return ErrSpam
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package ws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpamFingerprint(t *testing.T) {
+	long := strings.Repeat("buy cheap followers ", 2)
+
+	tests := []struct {
+		name       string
+		content    string
+		wantHash   bool
+		wantFanOut bool
+	}{
+		{"empty", "", false, false},
+		{"whitespace only", " \t\n ", false, false},
+		{"short text", "hi", true, false},
+		{"just under fan-out length", strings.Repeat("a", spamMinLength-1), true, false},
+		{"at fan-out length", strings.Repeat("a", spamMinLength), true, true},
+		{"padding does not count", "  hi  " + strings.Repeat(" ", spamMinLength), true, false},
+		{"long text", long, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, fanOut := spamFingerprint(tt.content)
+			assert.Equal(t, tt.wantHash, hash != "")
+			assert.Equal(t, tt.wantFanOut, fanOut)
+		})
+	}
+}
+
+func TestSpamFingerprintIgnoresCaseAndSpacing(t *testing.T) {
+	a, _ := spamFingerprint("Buy cheap   followers now")
+	b, _ := spamFingerprint("  buy CHEAP followers\tnow\n")
+	c, _ := spamFingerprint("buy cheap followers later")
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+// checkSpam lets messages through when Redis cannot be reached, like the
+// rate limits, rather than blocking every sender.
+func TestCheckSpamFailsOpen(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer rdb.Close()
+	h := &Hub{redis: rdb}
+
+	for _, content := range []string{"", "hi", strings.Repeat("spam ", 10)} {
+		assert.NoError(t, h.checkSpam(context.Background(), "conv", "user", content))
+	}
+}
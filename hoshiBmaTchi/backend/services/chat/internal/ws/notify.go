@@ -0,0 +1,205 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	pendingNotificationPrefix = "chat:notify:"
+
+	// dueNotificationsKey is a sorted set of pending notifications, scored by
+	// when they are due. The instance that queued one flushes it on a timer;
+	// if that instance goes away first, the sweeper finds it here.
+	dueNotificationsKey   = "chat:notify:due"
+	dueNotificationsBatch = 500
+
+	// notificationDelay is how long messages to an offline user are collected
+	// before one notification goes out for all of them.
+	notificationDelay = 15 * time.Second
+
+	// pendingNotificationTTL keeps a pending notification until the sweeper
+	// has had a chance to flush it.
+	pendingNotificationTTL = notificationDelay + 2*expirySweepInterval
+)
+
+func pendingNotificationKey(userID, conversationID string) string {
+	return pendingNotificationPrefix + dueNotificationMember(userID, conversationID)
+}
+
+func dueNotificationMember(userID, conversationID string) string {
+	return userID + ":" + conversationID
+}
+
+// queueOfflineNotifications counts a new message towards the pending
+// notification of every recipient without an open socket. The first message
+// of a burst schedules the notification; later ones only bump the counter, on
+// whichever instance they arrive.
+func (h *Hub) queueOfflineNotifications(msg *domain.Message, recipientIDs []string) {
+	if h.events == nil || msg.MediaType == domain.MediaTypeSystem {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	senderID := msg.SenderID.String()
+	conversationID := msg.ConversationID.String()
+
+	for _, userID := range recipientIDs {
+		if userID == senderID {
+			continue
+		}
+		if online, err := h.IsOnline(ctx, userID); err != nil || online {
+			continue
+		}
+
+		key := pendingNotificationKey(userID, conversationID)
+		pipe := h.redis.TxPipeline()
+		count := pipe.HIncrBy(ctx, key, "count", 1)
+		pipe.HSet(ctx, key, "sender_id", senderID, "preview", msg.Preview(), "sender:"+senderID, 1)
+		pipe.Expire(ctx, key, pendingNotificationTTL)
+		// NX keeps the due time of the burst's first message.
+		pipe.ZAddNX(ctx, dueNotificationsKey, redis.Z{
+			Score:  float64(time.Now().Add(notificationDelay).Unix()),
+			Member: dueNotificationMember(userID, conversationID),
+		})
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("Failed to queue notification for %s: %v", userID, err)
+			continue
+		}
+
+		if count.Val() == 1 {
+			userID := userID
+			time.AfterFunc(notificationDelay, func() {
+				h.flushNotification(userID, conversationID)
+			})
+		}
+	}
+}
+
+// flushDueNotifications flushes pending notifications whose timer was lost
+// with the instance that set it. It runs under the sweeper lock.
+func (h *Hub) flushDueNotifications(ctx context.Context) {
+	due, err := h.redis.ZRangeByScore(ctx, dueNotificationsKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().Add(-notificationDelay).Unix(), 10),
+		Count: dueNotificationsBatch,
+	}).Result()
+	if err != nil {
+		log.Printf("Failed to list due notifications: %v", err)
+		return
+	}
+	for _, member := range due {
+		userID, conversationID, ok := strings.Cut(member, ":")
+		if !ok {
+			h.redis.ZRem(ctx, dueNotificationsKey, member)
+			continue
+		}
+		h.flushNotification(userID, conversationID)
+	}
+}
+
+// flushNotification publishes the collected messages as one notification,
+// unless the user came online or muted the conversation in the meantime.
+// Message requests never notify. The notifications service applies the
+// user's preferences for message notifications when it delivers them. Only
+// the caller that takes the notification off the due set flushes it, so the
+// timer and the sweeper cannot both send it.
+func (h *Hub) flushNotification(userID, conversationID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	claimed, err := h.redis.ZRem(ctx, dueNotificationsKey, dueNotificationMember(userID, conversationID)).Result()
+	if err != nil {
+		log.Printf("Failed to claim pending notification for %s: %v", userID, err)
+		return
+	}
+	if claimed == 0 {
+		return
+	}
+
+	key := pendingNotificationKey(userID, conversationID)
+	pipe := h.redis.TxPipeline()
+	fields := pipe.HGetAll(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to read pending notification for %s: %v", userID, err)
+		return
+	}
+
+	pending := fields.Val()
+	count, _ := strconv.Atoi(pending["count"])
+	if count == 0 {
+		return
+	}
+	senders := 0
+	for field := range pending {
+		if strings.HasPrefix(field, "sender:") {
+			senders++
+		}
+	}
+
+	if online, err := h.IsOnline(ctx, userID); err != nil || online {
+		return
+	}
+
+	participant, err := h.repo.GetParticipant(ctx, conversationID, userID)
+	if err != nil || participant.IsMuted(time.Now()) || participant.IsRequest {
+		return
+	}
+
+	conv, err := h.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		log.Printf("Failed to load conversation %s for notification: %v", conversationID, err)
+		return
+	}
+
+	senderID := pending["sender_id"]
+	sender, err := h.users.GetUserProfile(ctx, senderID)
+	if err != nil {
+		log.Printf("Failed to load profile of %s for notification: %v", senderID, err)
+	}
+	senderName := sender.Username
+	if senderName == "" {
+		senderName = "Someone"
+	}
+
+	event := events.NotificationEvent{
+		RecipientID: userID,
+		SenderID:    senderID,
+		SenderName:  senderName,
+		SenderImage: sender.ProfilePictureURL,
+		Type:        events.TypeMessage,
+		EntityID:    conversationID,
+		Message:     notificationText(conv, pending["preview"], count, senders),
+	}
+	if err := h.events.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish message notification for %s: %v", userID, err)
+	}
+}
+
+// notificationText is shown after the sender's name, e.g. "alice sent you 3
+// messages". In groups the sender is whoever wrote last.
+func notificationText(conv *domain.Conversation, preview string, count, senders int) string {
+	switch {
+	case !conv.IsGroup && count == 1:
+		return "messaged you: " + preview
+	case !conv.IsGroup:
+		return fmt.Sprintf("sent you %d messages", count)
+	case count == 1:
+		return fmt.Sprintf("in %s: %s", conv.Name, preview)
+	case senders > 1:
+		return fmt.Sprintf("and %d others sent %d messages in %s", senders-1, count, conv.Name)
+	default:
+		return fmt.Sprintf("sent %d messages in %s", count, conv.Name)
+	}
+}
@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/repositories"
+)
+
+const participantCacheTTL = 30 * time.Second
+
+// maxCachedConversations caps the cache; past it, expired entries are swept.
+const maxCachedConversations = 10000
+
+type participantEntry struct {
+	userIDs   []string
+	expiresAt time.Time
+}
+
+// participantCache keeps the member list of recently active conversations so
+// that every frame does not cost a participants query.
+type participantCache struct {
+	repo    *repositories.ChatRepository
+	entries map[string]participantEntry
+	mu      sync.RWMutex
+}
+
+func newParticipantCache(repo *repositories.ChatRepository) *participantCache {
+	return &participantCache{
+		repo:    repo,
+		entries: make(map[string]participantEntry),
+	}
+}
+
+func (pc *participantCache) Get(ctx context.Context, conversationID string) ([]string, error) {
+	pc.mu.RLock()
+	entry, ok := pc.entries[conversationID]
+	pc.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.userIDs, nil
+	}
+
+	userIDs, err := pc.repo.GetParticipantIDs(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	pc.mu.Lock()
+	if len(pc.entries) >= maxCachedConversations {
+		now := time.Now()
+		for id, e := range pc.entries {
+			if now.After(e.expiresAt) {
+				delete(pc.entries, id)
+			}
+		}
+	}
+	pc.entries[conversationID] = participantEntry{
+		userIDs:   userIDs,
+		expiresAt: time.Now().Add(participantCacheTTL),
+	}
+	pc.mu.Unlock()
+
+	return userIDs, nil
+}
+
+func (pc *participantCache) Invalidate(conversationID string) {
+	pc.mu.Lock()
+	delete(pc.entries, conversationID)
+	pc.mu.Unlock()
+}
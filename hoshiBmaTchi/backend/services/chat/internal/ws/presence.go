@@ -0,0 +1,97 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	lastSeenKeyPrefix = "chat:last_seen:"
+	lastSeenTTL       = 30 * 24 * time.Hour
+)
+
+type Presence struct {
+	UserID   string     `json:"user_id"`
+	Online   bool       `json:"online"`
+	Devices  int        `json:"devices"`
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+func lastSeenKey(userID string) string {
+	return lastSeenKeyPrefix + userID
+}
+
+func (h *Hub) touchLastSeen(ctx context.Context, userID string) error {
+	return h.redis.Set(ctx, lastSeenKey(userID), time.Now().Unix(), lastSeenTTL).Err()
+}
+
+// Heartbeat is called from the WritePump ping loop. It keeps the device entry
+// alive and moves the user's last-seen timestamp forward.
+func (h *Hub) Heartbeat(ctx context.Context, client *Client) {
+	if err := h.refreshDevice(ctx, client); err != nil {
+		log.Printf("Failed to refresh device %s for user %s: %v", client.ID, client.UserID, err)
+	}
+	if err := h.touchLastSeen(ctx, client.UserID); err != nil {
+		log.Printf("Failed to update last seen for user %s: %v", client.UserID, err)
+	}
+}
+
+func (h *Hub) GetPresence(ctx context.Context, userIDs []string) ([]Presence, error) {
+	presences := make([]Presence, 0, len(userIDs))
+	if len(userIDs) == 0 {
+		return presences, nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = lastSeenKey(id)
+	}
+
+	lastSeen, err := h.redis.MGet(ctx, keys...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	for i, id := range userIDs {
+		devices, err := h.DeviceCount(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		p := Presence{UserID: id, Online: devices > 0, Devices: devices}
+		if raw, ok := lastSeen[i].(string); ok {
+			if ts, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				seen := time.Unix(ts, 0)
+				p.LastSeen = &seen
+			}
+		}
+		presences = append(presences, p)
+	}
+	return presences, nil
+}
+
+// announcePresence tells everyone who shares a conversation with the user
+// that they came online or went offline. It only runs when the first device
+// connects or the last one disconnects.
+func (h *Hub) announcePresence(ctx context.Context, userID string, online bool) {
+	contacts, err := h.repo.GetContactIDs(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load contacts of user %s: %v", userID, err)
+		return
+	}
+
+	frame := map[string]interface{}{
+		"type":      "presence",
+		"user_id":   userID,
+		"online":    online,
+		"last_seen": time.Now(),
+	}
+	if msgBytes, err := json.Marshal(frame); err == nil {
+		h.SendToUsers(contacts, msgBytes)
+	}
+}
@@ -0,0 +1,116 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBlocked               = errors.New("you can't message this user")
+	ErrBlockCheckUnavailable = errors.New("can't reach this user right now, try again later")
+)
+
+// Block lookups go to the users service. When it cannot be reached, actions
+// that reach another user (sending, starting conversations, calling) are
+// refused with ErrBlockCheckUnavailable, since letting them through would
+// let a blocked user in. Filtering what a user sees lets everything through
+// instead, so an outage does not hide whole conversations.
+
+func (h *Hub) blockedUserIDs(ctx context.Context, userID string) map[string]bool {
+	ids, err := h.users.BlockedUserIDs(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load block relations of user %s: %v", userID, err)
+		return map[string]bool{}
+	}
+	return ids
+}
+
+// CheckBlocked returns ErrBlocked if the user and any of the others have
+// blocked one another, and ErrBlockCheckUnavailable if that is unknown.
+func (h *Hub) CheckBlocked(ctx context.Context, userID string, otherIDs ...string) error {
+	for _, otherID := range otherIDs {
+		blocked, err := h.users.IsBlocked(ctx, userID, otherID)
+		if err != nil {
+			log.Printf("Failed to check block between %s and %s: %v", userID, otherID, err)
+			return ErrBlockCheckUnavailable
+		}
+		if blocked {
+			return ErrBlocked
+		}
+	}
+	return nil
+}
+
+// CanSend checks whether the sender may post in a conversation. In a DM a
+// block in either direction stops the message. Groups stay open; blocked
+// members simply do not see each other's messages.
+func (h *Hub) CanSend(ctx context.Context, conversationID, senderID string) error {
+	conv, err := h.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conv.IsGroup {
+		return nil
+	}
+
+	userIDs, err := h.participants.Get(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		if id != senderID {
+			return h.CheckBlocked(ctx, senderID, id)
+		}
+	}
+	return nil
+}
+
+// OpenDirectConversation returns the DM between two users, creating it if
+// needed. A new DM to a private account that has no follow relation with the
+// sender goes to the recipient's message requests.
+func (h *Hub) OpenDirectConversation(ctx context.Context, senderID, recipientID string) (*domain.Conversation, error) {
+	if err := h.CheckBlocked(ctx, senderID, recipientID); err != nil {
+		return nil, err
+	}
+
+	existing, err := h.repo.FindDirectConversation(ctx, senderID, recipientID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	asRequest, err := h.users.NeedsMessageRequest(ctx, senderID, recipientID)
+	if err != nil {
+		log.Printf("Failed to check relationship of %s and %s: %v", senderID, recipientID, err)
+	}
+	return h.repo.CreateDirectConversation(ctx, senderID, recipientID, asRequest)
+}
+
+// HiddenSenderIDs lists the users whose messages in a group the viewer should
+// not see because of a block. DMs are never filtered.
+func (h *Hub) HiddenSenderIDs(ctx context.Context, conversationID, viewerID string) ([]string, error) {
+	conv, err := h.repo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !conv.IsGroup {
+		return nil, nil
+	}
+
+	return h.BlockedUserIDs(ctx, viewerID), nil
+}
+
+// BlockedUserIDs lists everyone the user blocked or was blocked by.
+func (h *Hub) BlockedUserIDs(ctx context.Context, userID string) []string {
+	var ids []string
+	for id := range h.blockedUserIDs(ctx, userID) {
+		ids = append(ids, id)
+	}
+	return ids
+}
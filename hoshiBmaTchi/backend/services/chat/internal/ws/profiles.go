@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/chat/internal/core/domain"
+)
+
+// groupNameMembers is how many members an unnamed group is called after.
+const groupNameMembers = 3
+
+// DescribeConversations fills in member profiles and the display name and
+// avatar of each conversation as the viewer sees it, with one users-service
+// call for the whole list. If the lookup fails the list goes out without
+// them, and clients look up the missing profiles themselves.
+func (h *Hub) DescribeConversations(ctx context.Context, viewerID string, convs []domain.Conversation) {
+	var userIDs []string
+	for _, conv := range convs {
+		for _, p := range conv.Participants {
+			userIDs = append(userIDs, p.UserID.String())
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	profiles, err := h.users.GetUserProfiles(ctx, userIDs)
+	if err != nil {
+		log.Printf("Failed to load profiles for conversations of %s: %v", viewerID, err)
+	}
+
+	for i := range convs {
+		conv := &convs[i]
+		for j := range conv.Participants {
+			p := &conv.Participants[j]
+			profile := profiles[p.UserID.String()]
+			p.Username = profile.Username
+			p.Name = profile.Name
+			p.ProfilePictureURL = profile.ProfilePictureURL
+		}
+		conv.DisplayName, conv.DisplayAvatarURL = displayOf(conv, viewerID)
+	}
+}
+
+// displayOf picks a conversation's name and picture for the viewer. Direct
+// conversations take the other person's; a conversation with oneself takes
+// one's own.
+func displayOf(conv *domain.Conversation, viewerID string) (string, string) {
+	var others []domain.Participant
+	var self *domain.Participant
+	for i, p := range conv.Participants {
+		if p.UserID.String() == viewerID {
+			self = &conv.Participants[i]
+		} else {
+			others = append(others, p)
+		}
+	}
+
+	if !conv.IsGroup {
+		switch {
+		case len(others) > 0:
+			return profileName(others[0]), others[0].ProfilePictureURL
+		case self != nil:
+			return profileName(*self), self.ProfilePictureURL
+		}
+		return conv.Name, conv.AvatarURL
+	}
+
+	if conv.Name != "" || len(others) == 0 {
+		return conv.Name, conv.AvatarURL
+	}
+	names := make([]string, 0, groupNameMembers)
+	for _, p := range others {
+		if len(names) == groupNameMembers {
+			break
+		}
+		if name := profileName(p); name != "" {
+			names = append(names, name)
+		}
+	}
+	name := strings.Join(names, ", ")
+	if rest := len(others) - len(names); rest > 0 && len(names) > 0 {
+		noun := "others"
+		if rest == 1 {
+			noun = "other"
+		}
+		name = fmt.Sprintf("%s and %d %s", name, rest, noun)
+	}
+	return name, conv.AvatarURL
+}
+
+// profileName prefers the full name, falling back to the username.
+func profileName(p domain.Participant) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Username
+}
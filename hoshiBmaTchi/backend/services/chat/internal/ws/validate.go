@@ -0,0 +1,145 @@
+package ws
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Error codes sent back in "error" frames. Clients switch on these, so they
+// must not change once shipped.
+const (
+	CodeInvalidFrame      = "invalid_frame"
+	CodeNotParticipant    = "not_participant"
+	CodeRateLimited       = "rate_limited"
+	CodeSpam              = "spam_detected"
+	CodeBlocked           = "blocked"
+	CodeInvalidAttachment = "invalid_attachment"
+	CodeInvalidSignal     = "invalid_signal"
+	CodeCallBusy          = "call_busy"
+	CodeCallNotFound      = "call_not_found"
+	CodeInvalidCallState  = "invalid_call_state"
+	CodeUnavailable       = "unavailable"
+	CodeInternal          = "internal_error"
+)
+
+const (
+	// MaxContentLength caps the text of a message, in characters.
+	MaxContentLength = 2000
+	maxEmojiBytes    = 32
+	maxClientMsgID   = 64
+)
+
+// ErrorFrame tells a client why one of its frames was rejected.
+type ErrorFrame struct {
+	Type           string `json:"type"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	ClientMsgID    string `json:"client_msg_id,omitempty"`
+	Code           string `json:"code"`
+	Message        string `json:"message"`
+	RetryAfterMs   int64  `json:"retry_after_ms,omitempty"`
+}
+
+// clientMediaTypes are the media types a client may send. System messages
+// and call logs are only ever written by the server.
+var clientMediaTypes = map[string]bool{
+	"text":        true,
+	"gif":         true,
+	"image":       true,
+	"video":       true,
+	"audio":       true,
+	"file":        true,
+	"post_share":  true,
+	"story_share": true,
+	"reel_share":  true,
+}
+
+func isUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil
+}
+
+// validateFrame checks the shape of a client frame before anything is looked
+// up or stored. It returns a message for the client, or "" if the frame is
+// fine.
+func validateFrame(m WSMessage) string {
+	// A resume spans all of the user's conversations.
+	if m.Type == "resume" {
+		if m.Seq < 0 || m.Limit < 0 {
+			return "seq and limit must not be negative"
+		}
+		return ""
+	}
+
+	if !isUUID(m.ConversationID) {
+		return "conversation_id must be a valid ID"
+	}
+
+	switch m.Type {
+	case "typing_start", "typing_stop", "signal":
+		return ""
+
+	case "read":
+		if m.ID != "" && !isUUID(m.ID) {
+			return "id must be a valid message ID"
+		}
+
+	case "reaction":
+		if !isUUID(m.ID) {
+			return "id must be a valid message ID"
+		}
+		if m.Emoji == "" || len(m.Emoji) > maxEmojiBytes {
+			return "emoji must be a single emoji"
+		}
+
+	case "reaction_remove":
+		if !isUUID(m.ID) {
+			return "id must be a valid message ID"
+		}
+
+	case "edit":
+		if !isUUID(m.ID) {
+			return "id must be a valid message ID"
+		}
+		return validateContent(m.Content, true)
+
+	case "chat", "message", "":
+		return validateChatMessage(m)
+
+	default:
+		return "unknown frame type " + m.Type
+	}
+	return ""
+}
+
+func validateChatMessage(m WSMessage) string {
+	if m.MediaType != "" && !clientMediaTypes[m.MediaType] {
+		return "unsupported media_type " + m.MediaType
+	}
+	if m.ReplyToID != "" && !isUUID(m.ReplyToID) {
+		return "reply_to_id must be a valid message ID"
+	}
+	if m.AttachmentID != "" && !isUUID(m.AttachmentID) {
+		return "attachment_id must be a valid ID"
+	}
+	if len(m.ClientMsgID) > maxClientMsgID {
+		return "client_msg_id is too long"
+	}
+
+	hasMedia := m.AttachmentID != "" || m.MediaURL != ""
+	return validateContent(m.Content, !hasMedia)
+}
+
+func validateContent(content string, required bool) string {
+	if required && strings.TrimSpace(content) == "" {
+		return "content is empty"
+	}
+	if !utf8.ValidString(content) {
+		return "content must be valid UTF-8"
+	}
+	if utf8.RuneCountInString(content) > MaxContentLength {
+		return "content is too long"
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package ws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFrame(t *testing.T) {
+	conv := uuid.NewString()
+	msg := uuid.NewString()
+
+	tests := []struct {
+		name  string
+		frame WSMessage
+		want  string
+	}{
+		{"text message", WSMessage{Type: "chat", ConversationID: conv, Content: "hi"}, ""},
+		{"type defaults to chat", WSMessage{ConversationID: conv, Content: "hi"}, ""},
+		{"bad conversation id", WSMessage{Type: "chat", ConversationID: "not-a-uuid", Content: "hi"}, "conversation_id must be a valid ID"},
+		{"missing conversation id", WSMessage{Type: "typing_start"}, "conversation_id must be a valid ID"},
+		{"unknown type", WSMessage{Type: "poke", ConversationID: conv}, "unknown frame type poke"},
+		{"blank content", WSMessage{Type: "chat", ConversationID: conv, Content: "  \n"}, "content is empty"},
+		{"media without text", WSMessage{Type: "chat", ConversationID: conv, MediaType: "image", AttachmentID: msg}, ""},
+		{"content at limit", WSMessage{Type: "chat", ConversationID: conv, Content: strings.Repeat("a", MaxContentLength)}, ""},
+		{"oversize content", WSMessage{Type: "chat", ConversationID: conv, Content: strings.Repeat("a", MaxContentLength+1)}, "content is too long"},
+		{"limit counts characters", WSMessage{Type: "chat", ConversationID: conv, Content: strings.Repeat("é", MaxContentLength)}, ""},
+		{"invalid utf8", WSMessage{Type: "chat", ConversationID: conv, Content: "\xff"}, "content must be valid UTF-8"},
+		{"server media type", WSMessage{Type: "chat", ConversationID: conv, Content: "hi", MediaType: "system"}, "unsupported media_type system"},
+		{"bad reply id", WSMessage{Type: "chat", ConversationID: conv, Content: "hi", ReplyToID: "1"}, "reply_to_id must be a valid message ID"},
+		{"bad attachment id", WSMessage{Type: "chat", ConversationID: conv, AttachmentID: "1"}, "attachment_id must be a valid ID"},
+		{"long client id", WSMessage{Type: "chat", ConversationID: conv, Content: "hi", ClientMsgID: strings.Repeat("x", maxClientMsgID+1)}, "client_msg_id is too long"},
+		{"read all", WSMessage{Type: "read", ConversationID: conv}, ""},
+		{"read bad id", WSMessage{Type: "read", ConversationID: conv, ID: "1"}, "id must be a valid message ID"},
+		{"reaction", WSMessage{Type: "reaction", ConversationID: conv, ID: msg, Emoji: "👍"}, ""},
+		{"reaction bad id", WSMessage{Type: "reaction", ConversationID: conv, ID: "1", Emoji: "👍"}, "id must be a valid message ID"},
+		{"reaction without emoji", WSMessage{Type: "reaction", ConversationID: conv, ID: msg}, "emoji must be a single emoji"},
+		{"reaction oversize emoji", WSMessage{Type: "reaction", ConversationID: conv, ID: msg, Emoji: strings.Repeat("👍", 9)}, "emoji must be a single emoji"},
+		{"reaction remove bad id", WSMessage{Type: "reaction_remove", ConversationID: conv}, "id must be a valid message ID"},
+		{"edit bad id", WSMessage{Type: "edit", ConversationID: conv, Content: "hi"}, "id must be a valid message ID"},
+		{"edit to blank", WSMessage{Type: "edit", ConversationID: conv, ID: msg, Content: " "}, "content is empty"},
+		{"resume", WSMessage{Type: "resume", Seq: 42, Limit: 100}, ""},
+		{"resume from start", WSMessage{Type: "resume"}, ""},
+		{"resume negative seq", WSMessage{Type: "resume", Seq: -1}, "seq and limit must not be negative"},
+		{"resume negative limit", WSMessage{Type: "resume", Limit: -1}, "seq and limit must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateFrame(tt.frame))
+		})
+	}
+}
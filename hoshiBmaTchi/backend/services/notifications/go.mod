@@ -3,8 +3,11 @@ module github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications
 go 1.25.3
 
 require (
+	github.com/Hinsane5/hoshiBmaTchi/backend/proto v0.0.0-00010101000000-000000000000
 	github.com/rabbitmq/amqp091-go v1.10.0
 	gopkg.in/gomail.v2 v2.0.0-20160411212932-81ebce5c23df
 )
 
 require gopkg.in/alexcesaro/quotedprintable.v3 v3.0.0-20150716171945-2caba252f4dc // indirect
+
+replace github.com/Hinsane5/hoshiBmaTchi/backend/proto => ../../proto
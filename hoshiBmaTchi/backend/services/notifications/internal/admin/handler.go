@@ -0,0 +1,123 @@
+// Package admin serves the notification service's operator endpoints.
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/broker"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/consumer"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/repository"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+type Handler struct {
+	deadLetters *repository.DeadLetterRepository
+	broker      broker.Broker
+}
+
+func NewHandler(deadLetters *repository.DeadLetterRepository, b broker.Broker) *Handler {
+	return &Handler{deadLetters: deadLetters, broker: b}
+}
+
+// ListDeadLetters handles GET /admin/dead-letters?queue=&before=&limit=.
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultListLimit)))
+	if err != nil || limit <= 0 || limit > maxListLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxListLimit)})
+		return
+	}
+	var before uint64
+	if raw := c.Query("before"); raw != "" {
+		if before, err = strconv.ParseUint(raw, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid before"})
+			return
+		}
+	}
+
+	letters, err := h.deadLetters.List(c.Query("queue"), uint(before), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dead letters"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"dead_letters": letters})
+}
+
+// ReplayDeadLetter handles POST /admin/dead-letters/:id/replay.
+func (h *Handler) ReplayDeadLetter(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dead letter ID"})
+		return
+	}
+
+	letter, err := h.deadLetters.Get(uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dead letter not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch dead letter"})
+		return
+	}
+	if letter.Queue == "" {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Dead letter has no source queue to replay to"})
+		return
+	}
+
+	if err := consumer.Replay(c.Request.Context(), h.broker, *letter); err != nil {
+		log.Printf("Failed to replay dead letter %d: %v", letter.ID, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to republish message"})
+		return
+	}
+	// The message is already back on its queue, so a failed delete only
+	// leaves a stale entry behind.
+	if err := h.deadLetters.Delete(letter.ID); err != nil {
+		log.Printf("Failed to delete replayed dead letter %d: %v", letter.ID, err)
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Dead letter replayed", "queue": letter.Queue})
+}
+
+// RequireAdmin accepts only requests bearing an access token with the admin
+// role, signed with secret. Without a secret it refuses everything.
+func RequireAdmin(secret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(secret) == 0 {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Admin endpoints are not configured"})
+			return
+		}
+
+		tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if tokenString == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "No token provided"})
+			return
+		}
+
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return secret, nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+		if err != nil || !token.Valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+
+		claims, _ := token.Claims.(jwt.MapClaims)
+		if role, _ := claims["role"].(string); role != "admin" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			return
+		}
+		c.Next()
+	}
+}
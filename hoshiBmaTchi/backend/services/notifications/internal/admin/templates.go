@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/templates"
+)
+
+// TemplatesHandler previews email templates with their sample data.
+type TemplatesHandler struct {
+	renderer *templates.Renderer
+}
+
+func NewTemplatesHandler(renderer *templates.Renderer) *TemplatesHandler {
+	return &TemplatesHandler{renderer: renderer}
+}
+
+// ListEmailTemplates handles GET /admin/email-templates.
+func (h *TemplatesHandler) ListEmailTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"templates": h.renderer.Names(), "locales": h.renderer.Locales()})
+}
+
+// PreviewEmailTemplate handles GET
+// /admin/email-templates/:name/preview?locale=&format=. With format=html or
+// format=text it returns that body as is, for viewing in a browser;
+// otherwise the subject and both bodies as JSON.
+func (h *TemplatesHandler) PreviewEmailTemplate(c *gin.Context) {
+	name := c.Param("name")
+	email, err := h.renderer.Render(name, c.DefaultQuery("locale", templates.DefaultLocale), templates.Samples[name])
+	if errors.Is(err, templates.ErrUnknownTemplate) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Email template not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch c.Query("format") {
+	case "":
+		c.JSON(http.StatusOK, email)
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.HTML))
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(email.Text))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be html or text"})
+	}
+}
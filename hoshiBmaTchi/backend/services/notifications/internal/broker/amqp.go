@@ -0,0 +1,135 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	publishTimeout = 5 * time.Second
+
+	// prefetch bounds how many unacked messages a consumer holds at once.
+	prefetch = 20
+)
+
+// ErrNotConfirmed means RabbitMQ refused a published message.
+var ErrNotConfirmed = errors.New("broker did not confirm the message")
+
+// AMQP is a Broker backed by a RabbitMQ connection. It publishes on its own
+// channel in confirm mode, so a consumer's channel is never shared with it.
+type AMQP struct {
+	conn *amqp.Connection
+	pub  *amqp.Channel
+	mu   sync.Mutex
+}
+
+func NewAMQP(conn *amqp.Connection) (*AMQP, error) {
+	pub, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := pub.Confirm(false); err != nil {
+		pub.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	return &AMQP{conn: conn, pub: pub}, nil
+}
+
+func (b *AMQP) Publish(ctx context.Context, exchange, routingKey string, msg Message) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	b.mu.Lock()
+	confirm, err := b.pub.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.ID,
+		Timestamp:    time.Now(),
+		Headers:      amqp.Table(msg.Headers),
+		Body:         msg.Body,
+	})
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return ErrNotConfirmed
+	}
+	return nil
+}
+
+func (b *AMQP) Consume(queue string) (<-chan Delivery, error) {
+	ch, err := b.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+	msgs, err := ch.Consume(queue, "", false, false, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to consume %s: %w", queue, err)
+	}
+
+	out := make(chan Delivery)
+	go func() {
+		defer close(out)
+		defer ch.Close()
+		for d := range msgs {
+			d := d
+			out <- Delivery{
+				Message: Message{
+					ID:         d.MessageId,
+					RoutingKey: d.RoutingKey,
+					Body:       d.Body,
+					Headers:    d.Headers,
+				},
+				Redelivered: d.Redelivered,
+				ack:         func() error { return d.Ack(false) },
+				nack:        func(requeue bool) error { return d.Nack(false, requeue) },
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (b *AMQP) DeclareDelayQueue(name, target string, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err := b.pub.QueueDeclare(name, true, false, false, false, amqp.Table{
+		"x-message-ttl":             ttl.Milliseconds(),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": target,
+	})
+	return err
+}
+
+// DeclareDeadLetters declares a fanout exchange and the queue behind it that
+// collects messages the pipeline gave up on.
+func (b *AMQP) DeclareDeadLetters(exchange, queue string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.pub.ExchangeDeclare(exchange, "fanout", true, false, false, false, nil); err != nil {
+		return err
+	}
+	if _, err := b.pub.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return err
+	}
+	return b.pub.QueueBind(queue, "", exchange, false, nil)
+}
+
+func (b *AMQP) Close() error {
+	return b.pub.Close()
+}
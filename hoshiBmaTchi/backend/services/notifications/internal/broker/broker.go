@@ -0,0 +1,48 @@
+// Package broker is the slice of RabbitMQ the notification pipeline uses,
+// behind an interface so the pipeline can run against an in-memory fake.
+package broker
+
+import (
+	"context"
+	"time"
+)
+
+// Message is a message as published or delivered.
+type Message struct {
+	ID         string
+	RoutingKey string
+	Body       []byte
+	Headers    map[string]interface{}
+}
+
+// Delivery is a consumed message. It must be acked or nacked exactly once.
+type Delivery struct {
+	Message
+	Redelivered bool
+
+	ack  func() error
+	nack func(requeue bool) error
+}
+
+func (d Delivery) Ack() error {
+	return d.ack()
+}
+
+// Nack rejects the delivery, putting it back on its queue when requeue is
+// set and dropping it otherwise.
+func (d Delivery) Nack(requeue bool) error {
+	return d.nack(requeue)
+}
+
+type Broker interface {
+	// Publish sends msg and returns once the broker has accepted it. The
+	// default exchange "" routes straight to the queue named by routingKey.
+	Publish(ctx context.Context, exchange, routingKey string, msg Message) error
+
+	// Consume delivers messages from queue until the broker goes away.
+	Consume(queue string) (<-chan Delivery, error)
+
+	// DeclareDelayQueue declares a queue that holds each message for ttl and
+	// then moves it to the queue named target.
+	DeclareDelayQueue(name, target string, ttl time.Duration) error
+}
@@ -0,0 +1,195 @@
+package broker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-memory Broker for tests. Exchanges are fanouts set up with
+// Bind and delay queues hold messages until Expire is called. Consumers of a
+// queue share one buffered delivery channel.
+type Memory struct {
+	mu       sync.Mutex
+	queues   map[string]*memQueue
+	bindings map[string][]string
+	delays   map[string]string
+
+	// PublishErr, when set, fails every publish.
+	PublishErr error
+}
+
+type memQueue struct {
+	pending    []Delivery
+	deliveries chan Delivery
+	acked      []Message
+	dropped    []Message
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		queues:   make(map[string]*memQueue),
+		bindings: make(map[string][]string),
+		delays:   make(map[string]string),
+	}
+}
+
+// Bind routes everything published to exchange into queue.
+func (m *Memory) Bind(exchange, queue string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bindings[exchange] = append(m.bindings[exchange], queue)
+}
+
+func (m *Memory) Publish(_ context.Context, exchange, routingKey string, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.PublishErr != nil {
+		return m.PublishErr
+	}
+
+	msg.RoutingKey = routingKey
+	msg.Headers = copyHeaders(msg.Headers)
+	msg.Body = append([]byte(nil), msg.Body...)
+	if exchange == "" {
+		m.enqueue(routingKey, msg, false)
+		return nil
+	}
+	for _, queue := range m.bindings[exchange] {
+		m.enqueue(queue, msg, false)
+	}
+	return nil
+}
+
+func (m *Memory) Consume(queue string) (<-chan Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q := m.queue(queue)
+	if q.deliveries == nil {
+		q.deliveries = make(chan Delivery, 1024)
+		for _, d := range q.pending {
+			q.deliveries <- d
+		}
+		q.pending = nil
+	}
+	return q.deliveries, nil
+}
+
+func (m *Memory) DeclareDelayQueue(name, target string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queue(name)
+	m.delays[name] = target
+	return nil
+}
+
+// Expire moves everything waiting in a delay queue on to its target, as if
+// its TTL had passed.
+func (m *Memory) Expire(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	target, ok := m.delays[name]
+	if !ok {
+		return
+	}
+	q := m.queue(name)
+	waiting := q.pending
+	q.pending = nil
+	for _, d := range waiting {
+		msg := d.Message
+		msg.RoutingKey = target
+		m.enqueue(target, msg, false)
+	}
+}
+
+// Pending returns the messages waiting in a queue that has no consumer.
+func (m *Memory) Pending(queue string) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var msgs []Message
+	for _, d := range m.queue(queue).pending {
+		msgs = append(msgs, d.Message)
+	}
+	return msgs
+}
+
+// Acked returns the messages consumed from queue and acked.
+func (m *Memory) Acked(queue string) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.queue(queue).acked...)
+}
+
+// Dropped returns the messages consumed from queue and nacked without
+// requeue.
+func (m *Memory) Dropped(queue string) []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.queue(queue).dropped...)
+}
+
+// Close ends every consumer's delivery channel.
+func (m *Memory) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, q := range m.queues {
+		if q.deliveries != nil {
+			close(q.deliveries)
+			q.deliveries = nil
+		}
+	}
+}
+
+func (m *Memory) queue(name string) *memQueue {
+	q, ok := m.queues[name]
+	if !ok {
+		q = &memQueue{}
+		m.queues[name] = q
+	}
+	return q
+}
+
+// enqueue must be called with m.mu held.
+func (m *Memory) enqueue(queue string, msg Message, redelivered bool) {
+	q := m.queue(queue)
+	var once sync.Once
+	d := Delivery{Message: msg, Redelivered: redelivered}
+	d.ack = func() error {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			q.acked = append(q.acked, msg)
+		})
+		return nil
+	}
+	d.nack = func(requeue bool) error {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if requeue {
+				m.enqueue(queue, msg, true)
+			} else {
+				q.dropped = append(q.dropped, msg)
+			}
+		})
+		return nil
+	}
+
+	// Delay queues never have consumers; their messages wait for Expire.
+	if _, delayed := m.delays[queue]; delayed || q.deliveries == nil {
+		q.pending = append(q.pending, d)
+		return
+	}
+	q.deliveries <- d
+}
+
+func copyHeaders(h map[string]interface{}) map[string]interface{} {
+	if h == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,207 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// settingsCacheTTL bounds how long a preference change takes to apply.
+const settingsCacheTTL = time.Minute
+
+// maxCachedUsers caps the cache; past it, expired entries are swept.
+const maxCachedUsers = 10000
+
+// Delivery channels, as stored in the users service.
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+	ChannelOff   = "off"
+)
+
+// Digest frequencies, as stored in the users service.
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Settings is what the notifications service needs to know to deliver to a
+// user.
+type Settings struct {
+	// PushEnabled is the global switch for live pushes. In-app
+	// notifications are still stored when it is off.
+	PushEnabled bool
+
+	// Channels maps a preference kind (likes, comments, ...) to a channel.
+	// Kinds missing from it are delivered in-app.
+	Channels map[string]string
+
+	// Digest is how often unread notifications are summed up by email.
+	Digest string
+
+	Email string
+
+	// Locale is the language emails are written in; empty means the default.
+	Locale string
+}
+
+type settingsEntry struct {
+	settings Settings
+	expires  time.Time
+}
+
+// UserServiceClient looks up delivery settings in the users service, caching
+// them briefly since every notification needs them. A nil client reports the
+// defaults, so notifications keep flowing without the users service.
+type UserServiceClient struct {
+	client pb.UserServiceClient
+	conn   *grpc.ClientConn
+
+	mu       sync.Mutex
+	settings map[string]settingsEntry
+}
+
+func NewUserServiceClient(address string) (*UserServiceClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	log.Printf("Connected to user service at %s", address)
+
+	return &UserServiceClient{
+		client:   pb.NewUserServiceClient(conn),
+		conn:     conn,
+		settings: make(map[string]settingsEntry),
+	}, nil
+}
+
+// GetSettings returns the user's delivery settings. The email address is
+// only fetched when some kind is set to email or a digest is on.
+func (c *UserServiceClient) GetSettings(ctx context.Context, userID string) (Settings, error) {
+	if c == nil {
+		return Settings{PushEnabled: true, Digest: DigestOff}, nil
+	}
+
+	c.mu.Lock()
+	entry, ok := c.settings[userID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.settings, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetSettings(ctx, &pb.GetSettingsRequest{UserId: userID})
+	if err != nil {
+		return Settings{}, err
+	}
+
+	settings := SettingsFromResponse(resp)
+	needsEmail := settings.Digest != DigestOff
+	for _, channel := range settings.Channels {
+		needsEmail = needsEmail || channel == ChannelEmail
+	}
+	if needsEmail {
+		email, err := c.client.GetUserEmail(ctx, &pb.GetUserEmailRequest{UserId: userID})
+		if err != nil {
+			return Settings{}, err
+		}
+		settings.Email = email.Email
+	}
+
+	c.mu.Lock()
+	if len(c.settings) >= maxCachedUsers {
+		now := time.Now()
+		for id, e := range c.settings {
+			if now.After(e.expires) {
+				delete(c.settings, id)
+			}
+		}
+	}
+	c.settings[userID] = settingsEntry{settings: settings, expires: time.Now().Add(settingsCacheTTL)}
+	c.mu.Unlock()
+	return settings, nil
+}
+
+// DisplayName returns the user's name, or their username if they have none.
+func (c *UserServiceClient) DisplayName(ctx context.Context, userID string) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	profile, err := c.client.GetUserProfile(ctx, &pb.GetUserProfileRequest{UserId: userID})
+	if err != nil {
+		return "", err
+	}
+	if profile.Name != "" {
+		return profile.Name, nil
+	}
+	return profile.Username, nil
+}
+
+// SettingsFromResponse maps the users service's settings to delivery
+// settings. With email turned off, kinds set to email are delivered in-app
+// and the digest is off.
+func SettingsFromResponse(resp *pb.GetSettingsResponse) Settings {
+	prefs := resp.GetNotificationPreferences()
+	settings := Settings{
+		PushEnabled: resp.GetEnablePush(),
+		Channels: map[string]string{
+			"likes":         channelName(prefs.GetLikes()),
+			"comments":      channelName(prefs.GetComments()),
+			"follows":       channelName(prefs.GetFollows()),
+			"mentions":      channelName(prefs.GetMentions()),
+			"story_replies": channelName(prefs.GetStoryReplies()),
+			"messages":      channelName(prefs.GetMessages()),
+		},
+		Digest: digestName(prefs.GetDigest()),
+		Locale: resp.GetLocale(),
+	}
+	if !resp.GetEnableEmail() {
+		for kind, channel := range settings.Channels {
+			if channel == ChannelEmail {
+				settings.Channels[kind] = ChannelInApp
+			}
+		}
+		settings.Digest = DigestOff
+	}
+	return settings
+}
+
+func channelName(channel pb.NotificationChannel) string {
+	switch channel {
+	case pb.NotificationChannel_CHANNEL_EMAIL:
+		return ChannelEmail
+	case pb.NotificationChannel_CHANNEL_OFF:
+		return ChannelOff
+	}
+	return ChannelInApp
+}
+
+func digestName(digest pb.DigestFrequency) string {
+	switch digest {
+	case pb.DigestFrequency_DIGEST_DAILY:
+		return DigestDaily
+	case pb.DigestFrequency_DIGEST_WEEKLY:
+		return DigestWeekly
+	}
+	return DigestOff
+}
+
+func (c *UserServiceClient) Close() error {
+	if c != nil && c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/users"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/clients"
+)
+
+func TestSettingsFromResponse(t *testing.T) {
+	prefs := &pb.NotificationPreferences{
+		Likes:    pb.NotificationChannel_CHANNEL_EMAIL,
+		Comments: pb.NotificationChannel_CHANNEL_OFF,
+		Mentions: pb.NotificationChannel_CHANNEL_EMAIL,
+		Digest:   pb.DigestFrequency_DIGEST_WEEKLY,
+	}
+
+	tests := []struct {
+		name         string
+		resp         *pb.GetSettingsResponse
+		wantChannels map[string]string
+		wantDigest   string
+	}{
+		{
+			name: "email enabled",
+			resp: &pb.GetSettingsResponse{EnableEmail: true, NotificationPreferences: prefs},
+			wantChannels: map[string]string{
+				"likes":         clients.ChannelEmail,
+				"comments":      clients.ChannelOff,
+				"follows":       clients.ChannelInApp,
+				"mentions":      clients.ChannelEmail,
+				"story_replies": clients.ChannelInApp,
+				"messages":      clients.ChannelInApp,
+			},
+			wantDigest: clients.DigestWeekly,
+		},
+		{
+			name: "email disabled delivers email kinds in-app",
+			resp: &pb.GetSettingsResponse{EnableEmail: false, NotificationPreferences: prefs},
+			wantChannels: map[string]string{
+				"likes":         clients.ChannelInApp,
+				"comments":      clients.ChannelOff,
+				"follows":       clients.ChannelInApp,
+				"mentions":      clients.ChannelInApp,
+				"story_replies": clients.ChannelInApp,
+				"messages":      clients.ChannelInApp,
+			},
+			wantDigest: clients.DigestOff,
+		},
+		{
+			name: "no preferences",
+			resp: &pb.GetSettingsResponse{EnableEmail: true},
+			wantChannels: map[string]string{
+				"likes":         clients.ChannelInApp,
+				"comments":      clients.ChannelInApp,
+				"follows":       clients.ChannelInApp,
+				"mentions":      clients.ChannelInApp,
+				"story_replies": clients.ChannelInApp,
+				"messages":      clients.ChannelInApp,
+			},
+			wantDigest: clients.DigestOff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := clients.SettingsFromResponse(tt.resp)
+			assert.Equal(t, tt.wantChannels, settings.Channels)
+			assert.Equal(t, tt.wantDigest, settings.Digest)
+		})
+	}
+}
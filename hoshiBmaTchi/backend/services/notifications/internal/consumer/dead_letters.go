@@ -0,0 +1,61 @@
+package consumer
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/broker"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/models"
+)
+
+// storeRetryDelay is how long RecordDeadLetters waits before putting back a
+// dead letter it could not store.
+const storeRetryDelay = 5 * time.Second
+
+// DeadLetterFromMessage turns a message off DeadLetterQueue into a record.
+func DeadLetterFromMessage(msg broker.Message) models.DeadLetter {
+	letter := models.DeadLetter{
+		MessageID: msg.ID,
+		Body:      string(msg.Body),
+		Attempts:  Attempt(msg.Headers),
+		FailedAt:  time.Now().UTC(),
+	}
+	letter.Queue, _ = msg.Headers[HeaderQueue].(string)
+	letter.Error, _ = msg.Headers[HeaderError].(string)
+	if failed, ok := msg.Headers[HeaderFailed].(string); ok {
+		if t, err := time.Parse(time.RFC3339, failed); err == nil {
+			letter.FailedAt = t
+		}
+	}
+	return letter
+}
+
+// RecordDeadLetters moves messages from DeadLetterQueue into store until the
+// broker closes the delivery channel.
+func RecordDeadLetters(b broker.Broker, store func(*models.DeadLetter) error) error {
+	deliveries, err := b.Consume(DeadLetterQueue)
+	if err != nil {
+		return err
+	}
+	for d := range deliveries {
+		letter := DeadLetterFromMessage(d.Message)
+		if err := store(&letter); err != nil {
+			log.Printf("Failed to store dead letter %s: %v", d.ID, err)
+			time.Sleep(storeRetryDelay)
+			d.Nack(true)
+			continue
+		}
+		d.Ack()
+	}
+	return nil
+}
+
+// Replay puts a dead letter back on the queue it came from as a fresh
+// message, with its full retry budget.
+func Replay(ctx context.Context, b broker.Broker, letter models.DeadLetter) error {
+	return b.Publish(ctx, "", letter.Queue, broker.Message{
+		ID:   letter.MessageID,
+		Body: []byte(letter.Body),
+	})
+}
@@ -0,0 +1,82 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/broker"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/clients"
+)
+
+// preferenceKinds maps each notification type to the preference that
+// decides where it goes.
+var preferenceKinds = map[string]string{
+	events.TypeLike:       "likes",
+	events.TypeStoryLike:  "likes",
+	events.TypeComment:    "comments",
+	events.TypeFollow:     "follows",
+	events.TypeMention:    "mentions",
+	events.TypeStoryReply: "story_replies",
+	events.TypeMessage:    "messages",
+}
+
+// InAppHandler stores a notification, pushing it live when push is set.
+type InAppHandler func(ctx context.Context, event events.NotificationEvent, push bool) error
+
+// EmailHandler sends a notification to an email address, written in locale.
+type EmailHandler func(ctx context.Context, event events.NotificationEvent, address, locale string) error
+
+// Deliver routes each event to the channel its recipient chose for its kind:
+// in-app, email or nowhere.
+func Deliver(users *clients.UserServiceClient, inApp InAppHandler, email EmailHandler) Handler {
+	return func(ctx context.Context, event events.NotificationEvent) error {
+		settings, err := users.GetSettings(ctx, event.RecipientID)
+		if err != nil {
+			return fmt.Errorf("failed to load settings for %s: %w", event.RecipientID, err)
+		}
+
+		switch settings.Channels[preferenceKinds[event.Type]] {
+		case clients.ChannelOff:
+			return nil
+		case clients.ChannelEmail:
+			if settings.Email == "" {
+				log.Printf("No email address for %s, dropping %s notification %s", event.RecipientID, event.Type, event.ID)
+				return nil
+			}
+			return email(ctx, event, settings.Email, settings.Locale)
+		default:
+			return inApp(ctx, event, settings.PushEnabled)
+		}
+	}
+}
+
+// TemplateNotification is the email template a notification is sent with.
+const TemplateNotification = "notification"
+
+// Email queues the notification as an email for the email consumer, which
+// retries SMTP failures on its own. link is where the email's button points.
+func Email(b broker.Broker, link string) EmailHandler {
+	return func(ctx context.Context, event events.NotificationEvent, address, locale string) error {
+		sender := event.SenderName
+		if sender == "" {
+			sender = "Someone"
+		}
+		task := events.EmailTask{
+			Email:    address,
+			Template: TemplateNotification,
+			Data: map[string]interface{}{
+				"summary": sender + " " + event.Message,
+				"link":    link,
+			},
+			Locale: locale,
+		}
+		body, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		return b.Publish(ctx, events.EmailExchange, events.EmailRoutingKey, broker.Message{ID: event.ID, Body: body})
+	}
+}
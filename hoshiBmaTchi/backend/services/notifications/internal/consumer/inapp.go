@@ -0,0 +1,71 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/models"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/repository"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/ws"
+)
+
+// AggregateWindow is how long after a notification is created further
+// events about the same entity fold into it rather than starting a new one.
+const AggregateWindow = 24 * time.Hour
+
+// aggregated lists the types whose events fold together. Mentions, follows,
+// replies and messages each carry something of their own, so every one is
+// shown.
+var aggregated = map[string]bool{
+	events.TypeLike:      true,
+	events.TypeComment:   true,
+	events.TypeStoryLike: true,
+}
+
+// InApp stores the notification and, if push is set, pushes it to the
+// recipient's open sockets. Likes and comments are aggregated; an aggregate
+// that gains an actor is pushed again as an update. A redelivered event
+// changes nothing and is not pushed.
+func InApp(repo *repository.NotificationRepository, hub *ws.Hub) InAppHandler {
+	return func(ctx context.Context, event events.NotificationEvent, push bool) error {
+		notif := models.Notification{
+			EventID:     &event.ID,
+			RecipientID: event.RecipientID,
+			SenderID:    event.SenderID,
+			SenderName:  event.SenderName,
+			SenderImage: event.SenderImage,
+			Type:        event.Type,
+			EntityID:    event.EntityID,
+			Message:     event.Message,
+			IsRead:      false,
+		}
+		if event.ID == "" {
+			notif.EventID = nil
+		}
+
+		if !aggregated[event.Type] {
+			notif.AddActor(models.Actor{ID: event.SenderID, Name: event.SenderName, Image: event.SenderImage})
+			created, err := repo.CreateOnce(&notif)
+			if err != nil {
+				return err
+			}
+			if created && push {
+				hub.SendNotification(event.RecipientID, models.NotificationPush{Event: models.PushCreated, Notification: notif})
+			}
+			return nil
+		}
+
+		result, err := repo.Aggregate(&notif, time.Now().Add(-AggregateWindow))
+		if err != nil || !push {
+			return err
+		}
+		switch result {
+		case repository.Created:
+			hub.SendNotification(event.RecipientID, models.NotificationPush{Event: models.PushCreated, Notification: notif})
+		case repository.Updated:
+			hub.SendNotification(event.RecipientID, models.NotificationPush{Event: models.PushUpdated, Notification: notif})
+		}
+		return nil
+	}
+}
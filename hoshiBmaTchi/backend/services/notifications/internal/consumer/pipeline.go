@@ -0,0 +1,153 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/broker"
+)
+
+// DeadLetterExchange receives every message a pipeline gives up on, and
+// DeadLetterQueue collects them for the admin endpoints.
+const (
+	DeadLetterExchange = "notifications.dlx"
+	DeadLetterQueue    = "notifications.dead_letters"
+)
+
+// Headers a pipeline sets on the messages it retries or dead-letters.
+const (
+	HeaderAttempt = "x-attempt"
+	HeaderError   = "x-error"
+	HeaderQueue   = "x-original-queue"
+	HeaderFailed  = "x-failed-at"
+)
+
+// MessageHandler processes one message body.
+type MessageHandler func(ctx context.Context, msg broker.Message) error
+
+// permanentError marks a failure that retrying cannot fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying, so the message goes straight to
+// the dead-letter queue.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// Backoff returns n delays doubling from base.
+func Backoff(base time.Duration, n int) []time.Duration {
+	delays := make([]time.Duration, n)
+	for i := range delays {
+		delays[i] = base << i
+	}
+	return delays
+}
+
+// Pipeline consumes a queue with manual acks. A message whose handler fails
+// is parked in a delay queue and comes back after the next backoff delay;
+// once the delays run out, or on a permanent error, it is published to
+// DeadLetterExchange. The original delivery is acked only after the retry
+// or dead letter has been confirmed, so a broker outage cannot lose it.
+type Pipeline struct {
+	broker broker.Broker
+	queue  string
+	delays []time.Duration
+}
+
+func NewPipeline(b broker.Broker, queue string, delays []time.Duration) *Pipeline {
+	return &Pipeline{broker: b, queue: queue, delays: delays}
+}
+
+// Declare sets up the pipeline's delay queues.
+func (p *Pipeline) Declare() error {
+	for i, delay := range p.delays {
+		if err := p.broker.DeclareDelayQueue(p.RetryQueue(i), p.queue, delay); err != nil {
+			return fmt.Errorf("failed to declare %s: %w", p.RetryQueue(i), err)
+		}
+	}
+	return nil
+}
+
+// RetryQueue names the delay queue for the given retry, counting from 0.
+func (p *Pipeline) RetryQueue(retry int) string {
+	return fmt.Sprintf("%s.retry.%d", p.queue, retry)
+}
+
+// Run consumes the queue until the broker closes the delivery channel.
+func (p *Pipeline) Run(ctx context.Context, handle MessageHandler) error {
+	deliveries, err := p.broker.Consume(p.queue)
+	if err != nil {
+		return err
+	}
+	for d := range deliveries {
+		p.Process(ctx, d, handle)
+	}
+	return nil
+}
+
+// Process handles one delivery and settles it.
+func (p *Pipeline) Process(ctx context.Context, d broker.Delivery, handle MessageHandler) {
+	err := handle(ctx, d.Message)
+	if err == nil {
+		d.Ack()
+		return
+	}
+
+	attempt := Attempt(d.Headers)
+	if !IsPermanent(err) && attempt < len(p.delays) {
+		log.Printf("Retrying message %s from %s in %s (attempt %d): %v", d.ID, p.queue, p.delays[attempt], attempt+1, err)
+		p.forward(ctx, d, "", p.RetryQueue(attempt), attempt+1, err)
+		return
+	}
+
+	log.Printf("Dead-lettering message %s from %s after %d attempts: %v", d.ID, p.queue, attempt+1, err)
+	p.forward(ctx, d, DeadLetterExchange, p.queue, attempt+1, err)
+}
+
+// forward publishes a copy of d and acks d. If the copy cannot be published
+// d is requeued instead, to be tried again.
+func (p *Pipeline) forward(ctx context.Context, d broker.Delivery, exchange, routingKey string, attempt int, cause error) {
+	headers := make(map[string]interface{}, len(d.Headers)+4)
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[HeaderAttempt] = int64(attempt)
+	headers[HeaderError] = cause.Error()
+	headers[HeaderQueue] = p.queue
+	headers[HeaderFailed] = time.Now().UTC().Format(time.RFC3339)
+
+	msg := broker.Message{ID: d.ID, Body: d.Body, Headers: headers}
+	if err := p.broker.Publish(ctx, exchange, routingKey, msg); err != nil {
+		log.Printf("Failed to forward message %s from %s, requeueing: %v", d.ID, p.queue, err)
+		d.Nack(true)
+		return
+	}
+	d.Ack()
+}
+
+// Attempt returns how many times a message has already failed.
+func Attempt(headers map[string]interface{}) int {
+	switch n := headers[HeaderAttempt].(type) {
+	case int:
+		return n
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	}
+	return 0
+}
@@ -0,0 +1,186 @@
+package consumer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/broker"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/consumer"
+)
+
+const queue = "notification_service_queue"
+
+func newPipeline(t *testing.T, retries int) (*broker.Memory, *consumer.Pipeline) {
+	t.Helper()
+	mq := broker.NewMemory()
+	mq.Bind(consumer.DeadLetterExchange, consumer.DeadLetterQueue)
+	p := consumer.NewPipeline(mq, queue, consumer.Backoff(time.Second, retries))
+	require.NoError(t, p.Declare())
+	return mq, p
+}
+
+func likeEvent(t *testing.T) []byte {
+	t.Helper()
+	body, err := json.Marshal(events.NotificationEvent{
+		Version:     events.SchemaVersion,
+		ID:          "7f1e9a52-3c1b-4c8e-9a0f-0d7b2c1e5a11",
+		Type:        events.TypeLike,
+		RecipientID: "1b4e28ba-2fa1-4d2e-883f-0016d3cca427",
+		SenderID:    "9c858901-8a57-4791-81fe-4c455b099bc9",
+		EntityID:    "c9b1f6a2-6a3f-4f0e-8d5e-2a1b3c4d5e6f",
+	})
+	require.NoError(t, err)
+	return body
+}
+
+// consumeOne feeds the next message on the queue through the pipeline.
+func consumeOne(t *testing.T, mq *broker.Memory, p *consumer.Pipeline, handle consumer.MessageHandler) {
+	t.Helper()
+	deliveries, err := mq.Consume(queue)
+	require.NoError(t, err)
+	select {
+	case d := <-deliveries:
+		p.Process(context.Background(), d, handle)
+	default:
+		t.Fatal("queue is empty")
+	}
+}
+
+func TestPipeline_AcksHandledMessage(t *testing.T) {
+	mq, p := newPipeline(t, 3)
+	require.NoError(t, mq.Publish(context.Background(), "", queue, broker.Message{ID: "m1", Body: likeEvent(t)}))
+
+	consumeOne(t, mq, p, func(ctx context.Context, msg broker.Message) error { return nil })
+
+	assert.Len(t, mq.Acked(queue), 1)
+	assert.Empty(t, mq.Pending(p.RetryQueue(0)))
+	assert.Empty(t, mq.Pending(consumer.DeadLetterQueue))
+}
+
+func TestPipeline_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	mq, p := newPipeline(t, 2)
+	require.NoError(t, mq.Publish(context.Background(), "", queue, broker.Message{ID: "m1", Body: likeEvent(t)}))
+
+	calls := 0
+	failing := func(ctx context.Context, msg broker.Message) error {
+		calls++
+		return errors.New("database is down")
+	}
+
+	for retry := 0; retry < 2; retry++ {
+		consumeOne(t, mq, p, failing)
+
+		waiting := mq.Pending(p.RetryQueue(retry))
+		require.Len(t, waiting, 1, "retry %d", retry)
+		assert.Equal(t, retry+1, consumer.Attempt(waiting[0].Headers))
+		assert.Equal(t, "m1", waiting[0].ID)
+
+		mq.Expire(p.RetryQueue(retry))
+	}
+
+	consumeOne(t, mq, p, failing)
+
+	assert.Equal(t, 3, calls)
+	assert.Len(t, mq.Acked(queue), 3, "every attempt is acked once forwarded")
+	dead := mq.Pending(consumer.DeadLetterQueue)
+	require.Len(t, dead, 1)
+
+	letter := consumer.DeadLetterFromMessage(dead[0])
+	assert.Equal(t, queue, letter.Queue)
+	assert.Equal(t, "m1", letter.MessageID)
+	assert.Equal(t, 3, letter.Attempts)
+	assert.Equal(t, "database is down", letter.Error)
+	assert.Equal(t, string(likeEvent(t)), letter.Body)
+}
+
+func TestPipeline_InvalidEventSkipsRetries(t *testing.T) {
+	mq, p := newPipeline(t, 3)
+	require.NoError(t, mq.Publish(context.Background(), "", queue, broker.Message{ID: "m1", Body: []byte(`{"type":"like"}`)}))
+
+	router := consumer.NewRouter()
+	router.Handle(func(ctx context.Context, event events.NotificationEvent) error { return nil }, events.Types...)
+	consumeOne(t, mq, p, func(ctx context.Context, msg broker.Message) error {
+		_, err := router.Dispatch(ctx, msg.Body)
+		return err
+	})
+
+	assert.Empty(t, mq.Pending(p.RetryQueue(0)))
+	dead := mq.Pending(consumer.DeadLetterQueue)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 1, consumer.Attempt(dead[0].Headers))
+}
+
+func TestPipeline_RequeuesWhenForwardingFails(t *testing.T) {
+	mq, p := newPipeline(t, 3)
+	require.NoError(t, mq.Publish(context.Background(), "", queue, broker.Message{ID: "m1", Body: likeEvent(t)}))
+	mq.PublishErr = errors.New("connection closed")
+
+	consumeOne(t, mq, p, func(ctx context.Context, msg broker.Message) error {
+		return errors.New("database is down")
+	})
+
+	assert.Empty(t, mq.Acked(queue))
+	deliveries, err := mq.Consume(queue)
+	require.NoError(t, err)
+	select {
+	case d := <-deliveries:
+		assert.True(t, d.Redelivered)
+		assert.Equal(t, "m1", d.ID)
+	default:
+		t.Fatal("message was lost")
+	}
+}
+
+func TestReplay_ResetsRetryBudget(t *testing.T) {
+	mq, p := newPipeline(t, 1)
+	require.NoError(t, mq.Publish(context.Background(), "", queue, broker.Message{ID: "m1", Body: likeEvent(t)}))
+
+	handled := 0
+	flaky := func(ctx context.Context, msg broker.Message) error {
+		handled++
+		if handled <= 2 {
+			return errors.New("database is down")
+		}
+		return nil
+	}
+
+	consumeOne(t, mq, p, flaky)
+	mq.Expire(p.RetryQueue(0))
+	consumeOne(t, mq, p, flaky)
+
+	dead := mq.Pending(consumer.DeadLetterQueue)
+	require.Len(t, dead, 1)
+	require.NoError(t, consumer.Replay(context.Background(), mq, consumer.DeadLetterFromMessage(dead[0])))
+
+	deliveries, err := mq.Consume(queue)
+	require.NoError(t, err)
+	d := <-deliveries
+	assert.Equal(t, 0, consumer.Attempt(d.Headers))
+	p.Process(context.Background(), d, flaky)
+
+	assert.Equal(t, 3, handled)
+	assert.Len(t, mq.Acked(queue), 3)
+}
+
+func TestPipeline_RunStopsWhenBrokerCloses(t *testing.T) {
+	mq, p := newPipeline(t, 3)
+	for _, id := range []string{"m1", "m2"} {
+		require.NoError(t, mq.Publish(context.Background(), "", queue, broker.Message{ID: id, Body: likeEvent(t)}))
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- p.Run(context.Background(), func(ctx context.Context, msg broker.Message) error { return nil })
+	}()
+
+	require.Eventually(t, func() bool { return len(mq.Acked(queue)) == 2 }, time.Second, 10*time.Millisecond)
+	mq.Close()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,57 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+)
+
+var (
+	ErrInvalidEvent = errors.New("invalid notification event")
+	ErrNoHandler    = errors.New("no handler for notification type")
+)
+
+// Handler processes one validated notification event.
+type Handler func(ctx context.Context, event events.NotificationEvent) error
+
+// Router validates notification events off the wire and hands each to the
+// handler registered for its type.
+type Router struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewRouter() *Router {
+	return &Router{handlers: make(map[string]Handler)}
+}
+
+// Handle registers h for the given event types, replacing any earlier
+// handler.
+func (r *Router) Handle(h Handler, eventTypes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range eventTypes {
+		r.handlers[t] = h
+	}
+}
+
+// Dispatch decodes body and runs its handler. ErrInvalidEvent and
+// ErrNoHandler come back marked Permanent: the message itself is at fault
+// and retrying it cannot help.
+func (r *Router) Dispatch(ctx context.Context, body []byte) (events.NotificationEvent, error) {
+	event, err := events.DecodeNotification(body)
+	if err != nil {
+		return event, Permanent(fmt.Errorf("%w: %v", ErrInvalidEvent, err))
+	}
+
+	r.mu.RLock()
+	h, ok := r.handlers[event.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return event, Permanent(fmt.Errorf("%w %q", ErrNoHandler, event.Type))
+	}
+	return event, h(ctx, event)
+}
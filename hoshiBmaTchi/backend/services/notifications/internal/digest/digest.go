@@ -0,0 +1,158 @@
+// Package digest emails users a summary of the notifications they have not
+// read, daily or weekly as each one chose.
+package digest
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/clients"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/mailer"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/repository"
+)
+
+const (
+	// Interval is how often the job looks for digests that are due.
+	Interval = time.Hour
+
+	// maxItems is how many notifications a digest lists; the rest are
+	// counted.
+	maxItems = 10
+
+	TemplateDigest = "digest"
+)
+
+var periods = map[string]time.Duration{
+	clients.DigestDaily:  24 * time.Hour,
+	clients.DigestWeekly: 7 * 24 * time.Hour,
+}
+
+// longestPeriod bounds how far back the job looks for recipients.
+const longestPeriod = 7 * 24 * time.Hour
+
+type Job struct {
+	repo   *repository.DigestRepository
+	users  *clients.UserServiceClient
+	mailer *mailer.Mailer
+	// link is where the digest's button points.
+	link string
+}
+
+func NewJob(repo *repository.DigestRepository, users *clients.UserServiceClient, m *mailer.Mailer, frontendURL string) *Job {
+	return &Job{repo: repo, users: users, mailer: m, link: frontendURL + "/notifications"}
+}
+
+// Run sends due digests every Interval until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+	for {
+		j.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends every digest that is due now.
+func (j *Job) RunOnce(ctx context.Context) {
+	// Postgres keeps microseconds, and claims compare times exactly.
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	recipients, err := j.repo.UnreadRecipients(now.Add(-longestPeriod))
+	if err != nil {
+		log.Printf("Failed to list digest recipients: %v", err)
+		return
+	}
+	sent := 0
+	for _, userID := range recipients {
+		if ctx.Err() != nil {
+			return
+		}
+		ok, err := j.send(ctx, userID, now)
+		if err != nil {
+			log.Printf("Failed to send digest to %s: %v", userID, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	if sent > 0 {
+		log.Printf(" [DIGEST] Sent %d digests", sent)
+	}
+}
+
+// send sends the user's digest if it is due and has something in it, and
+// reports whether it did.
+func (j *Job) send(ctx context.Context, userID string, now time.Time) (bool, error) {
+	settings, err := j.users.GetSettings(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	period, ok := periods[settings.Digest]
+	if !ok || settings.Email == "" {
+		return false, nil
+	}
+
+	prev, err := j.repo.LastDigest(userID)
+	if err != nil {
+		return false, err
+	}
+	if now.Sub(prev) < period {
+		return false, nil
+	}
+
+	// Cover the period, but never repeat what the last digest had.
+	since := now.Add(-period)
+	if prev.After(since) {
+		since = prev
+	}
+	items, total, err := j.repo.UnreadSince(userID, since, maxItems)
+	if err != nil || total == 0 {
+		return false, err
+	}
+
+	name, err := j.users.DisplayName(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := j.repo.ClaimDigest(userID, prev, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	summaries := make([]map[string]interface{}, 0, len(items))
+	for _, n := range items {
+		summary := n.Summary
+		if summary == "" {
+			summary = n.SenderName + " " + n.Message
+		}
+		summaries = append(summaries, map[string]interface{}{"summary": summary})
+	}
+	err = j.mailer.Send(events.EmailTask{
+		Email:    settings.Email,
+		Template: TemplateDigest,
+		Data: map[string]interface{}{
+			"name":   name,
+			"period": settings.Digest,
+			"count":  total,
+			"more":   total - int64(len(items)),
+			"items":  summaries,
+			"link":   j.link,
+		},
+		Locale: settings.Locale,
+	})
+	if err != nil {
+		if releaseErr := j.repo.ReleaseDigest(userID, prev, now); releaseErr != nil {
+			log.Printf("Failed to release digest claim for %s: %v", userID, releaseErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,68 @@
+// Package mailer turns events.EmailTask into email and sends it over SMTP.
+package mailer
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/gomail.v2"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/templates"
+)
+
+var ErrNoRecipient = errors.New("email task has no recipient")
+
+type Mailer struct {
+	dialer    *gomail.Dialer
+	from      string
+	templates *templates.Renderer
+}
+
+func New(dialer *gomail.Dialer, from string, renderer *templates.Renderer) *Mailer {
+	return &Mailer{dialer: dialer, from: from, templates: renderer}
+}
+
+// Compose builds the message for a task. Its errors mean the task itself is
+// bad, so retrying it will not help.
+func (m *Mailer) Compose(task events.EmailTask) (*gomail.Message, error) {
+	if task.Email == "" {
+		return nil, ErrNoRecipient
+	}
+
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", m.from)
+	msg.SetHeader("To", task.Email)
+
+	if task.Template == "" {
+		msg.SetHeader("Subject", task.Subject)
+		msg.SetBody("text/html", task.Body)
+		return msg, nil
+	}
+
+	email, err := m.templates.Render(task.Template, task.Locale, task.Data)
+	if err != nil {
+		return nil, err
+	}
+	msg.SetHeader("Subject", email.Subject)
+	// Clients show the last alternative they can display, so HTML goes last.
+	msg.SetBody("text/plain", email.Text)
+	msg.AddAlternative("text/html", email.HTML)
+	return msg, nil
+}
+
+func (m *Mailer) Deliver(msg *gomail.Message) error {
+	return m.dialer.DialAndSend(msg)
+}
+
+// Send composes and delivers a task.
+func (m *Mailer) Send(task events.EmailTask) error {
+	msg, err := m.Compose(task)
+	if err != nil {
+		return err
+	}
+	if err := m.Deliver(msg); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", task.Email, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package mailer_test
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/gomail.v2"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/mailer"
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/templates"
+)
+
+// smtpSink accepts one message without auth or TLS and hands its DATA back.
+func smtpSink(t *testing.T) (*gomail.Dialer, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { io.WriteString(conn, s+"\r\n") }
+
+		reply("220 sink")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 sink")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(l, "."))
+				}
+				received <- data.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	host, portStr, _ := net.SplitHostPort(ln.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	return gomail.NewDialer(host, port, "", ""), received
+}
+
+func newMailer(t *testing.T) (*mailer.Mailer, <-chan string) {
+	t.Helper()
+	renderer, err := templates.New()
+	require.NoError(t, err)
+	dialer, received := smtpSink(t)
+	return mailer.New(dialer, "no-reply@hoshibmatchi.test", renderer), received
+}
+
+func TestSend_TemplateHasTextAndHTMLParts(t *testing.T) {
+	m, received := newMailer(t)
+
+	err := m.Send(events.EmailTask{
+		Email:    "alice@example.com",
+		Template: events.TemplateOTP,
+		Locale:   "id",
+		Data:     map[string]interface{}{"code": "482913"},
+	})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-received))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Verifikasi email kamu", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(p)
+		require.NoError(t, err)
+		contentType, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+	require.Len(t, parts, 2)
+	assert.Contains(t, parts["text/plain"], "482913")
+	assert.Contains(t, parts["text/html"], "482913")
+	assert.Contains(t, parts["text/html"], `lang="id"`)
+}
+
+func TestSend_RawBodyIsSentAsHTML(t *testing.T) {
+	m, received := newMailer(t)
+
+	err := m.Send(events.EmailTask{Email: "bob@example.com", Subject: "Hello", Body: "<p>Hi</p>"})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-received))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", msg.Header.Get("Subject"))
+	assert.True(t, strings.HasPrefix(msg.Header.Get("Content-Type"), "text/html"))
+}
+
+func TestCompose_RejectsBadTasks(t *testing.T) {
+	m, _ := newMailer(t)
+
+	_, err := m.Compose(events.EmailTask{Template: events.TemplateWelcome})
+	assert.ErrorIs(t, err, mailer.ErrNoRecipient)
+
+	_, err = m.Compose(events.EmailTask{Email: "a@example.com", Template: "nope"})
+	assert.ErrorIs(t, err, templates.ErrUnknownTemplate)
+}
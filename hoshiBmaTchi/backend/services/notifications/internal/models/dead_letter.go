@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// DeadLetter is a message the consumers gave up on, kept until an admin
+// replays it.
+type DeadLetter struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Queue     string    `json:"queue" gorm:"index"`
+	MessageID string    `json:"message_id"`
+	Body      string    `json:"body" gorm:"type:text"`
+	Error     string    `json:"error"`
+	Attempts  int       `json:"attempts"`
+	FailedAt  time.Time `json:"failed_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
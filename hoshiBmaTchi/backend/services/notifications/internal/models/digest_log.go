@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// DigestLog records when a user was last sent a digest, so each one covers
+// only what is new and concurrent runs do not send it twice.
+type DigestLog struct {
+	UserID     string    `gorm:"primaryKey"`
+	LastSentAt time.Time `gorm:"not null"`
+}
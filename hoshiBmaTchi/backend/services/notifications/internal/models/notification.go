@@ -1,25 +1,159 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// maxActors is how many actors a notification keeps for display.
+const maxActors = 3
 
 type Notification struct {
 	gorm.Model
-	RecipientID string      `json:"recipient_id" gorm:"index"` 
-	SenderID    string      `json:"sender_id"`                
-	SenderName  string    `json:"sender_name"`               
-	SenderImage string    `json:"sender_image"`              
-	Type        string    `json:"type"`                      
-	EntityID    string      `json:"entity_id"`                 
-	Message     string    `json:"message"`                   
-	IsRead      bool      `json:"is_read" gorm:"default:false"`
-}
-
-type NotificationEvent struct {
-	RecipientID string   `json:"recipient_id"`
-	SenderID    string   `json:"sender_id"`
-	SenderName  string `json:"sender_name"`
-	SenderImage string `json:"sender_image"`
-	Type        string `json:"type"`      
-	EntityID    string   `json:"entity_id"` 
-	Message     string `json:"message"`
-}
\ No newline at end of file
+	// EventID is the ID of the event the notification came from, so a
+	// redelivered event is not stored twice.
+	EventID     *string `json:"-" gorm:"uniqueIndex"`
+	RecipientID string  `json:"recipient_id" gorm:"index"`
+	SenderID    string  `json:"sender_id"`
+	SenderName  string  `json:"sender_name"`
+	SenderImage string  `json:"sender_image"`
+	Type        string  `json:"type"`
+	EntityID    string  `json:"entity_id"`
+	Message     string  `json:"message"`
+	IsRead      bool    `json:"is_read" gorm:"default:false"`
+
+	// An aggregated notification stands for ActorCount people doing the same
+	// thing to the same entity. Sender* is the latest of them, Actors the
+	// latest few and Summary the sentence to show, e.g. "alice, bob and 8
+	// others liked your post".
+	ActorCount int    `json:"actor_count" gorm:"not null;default:1"`
+	Actors     Actors `json:"actors" gorm:"type:text"`
+	Summary    string `json:"summary"`
+}
+
+// NotificationActor records that an actor is counted in a notification, so
+// the same person liking twice is counted once.
+type NotificationActor struct {
+	NotificationID uint   `gorm:"primaryKey"`
+	ActorID        string `gorm:"primaryKey"`
+	CreatedAt      time.Time
+}
+
+type Actor struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// Actors is stored as a JSON column.
+type Actors []Actor
+
+func (a Actors) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(a)
+	return string(b), err
+}
+
+func (a *Actors) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return errors.New("actors: unsupported column type")
+	}
+	return json.Unmarshal(b, a)
+}
+
+// BackfillActor returns the sender of a notification stored before actors
+// were tracked, and lists them as its only actor, so they can be recorded in
+// NotificationActor before anyone else is counted. ok is false for
+// notifications that already track their actors.
+func (n *Notification) BackfillActor() (actor Actor, ok bool) {
+	if len(n.Actors) > 0 || n.SenderID == "" {
+		return Actor{}, false
+	}
+	actor = Actor{ID: n.SenderID, Name: n.SenderName, Image: n.SenderImage}
+	n.Actors = Actors{actor}
+	return actor, true
+}
+
+// AddActor counts a new actor in the notification and makes them its
+// sender. Callers must make sure the actor was not already counted.
+func (n *Notification) AddActor(actor Actor) {
+	if n.ActorCount == 0 {
+		n.ActorCount = 1
+	} else {
+		n.ActorCount++
+	}
+	n.SenderID = actor.ID
+	n.SenderName = actor.Name
+	n.SenderImage = actor.Image
+
+	actors := Actors{actor}
+	for _, a := range n.Actors {
+		if len(actors) == maxActors {
+			break
+		}
+		if a.ID != actor.ID {
+			actors = append(actors, a)
+		}
+	}
+	n.Actors = actors
+	n.Summary = summarize(n.Actors, n.ActorCount, n.Message)
+}
+
+// summarize names up to two actors and counts the rest.
+func summarize(actors Actors, count int, message string) string {
+	names := make([]string, 0, 2)
+	for _, a := range actors {
+		if len(names) == 2 {
+			break
+		}
+		name := a.Name
+		if name == "" {
+			name = "Someone"
+		}
+		names = append(names, name)
+	}
+
+	var who string
+	switch others := count - len(names); {
+	case len(names) == 0:
+		who = "Someone"
+	case others <= 0:
+		who = strings.Join(names, " and ")
+	case others == 1:
+		who = fmt.Sprintf("%s and 1 other", strings.Join(names, ", "))
+	default:
+		who = fmt.Sprintf("%s and %d others", strings.Join(names, ", "), others)
+	}
+	return who + " " + message
+}
+
+// Notification pushes tell clients whether to add the notification or to
+// replace the one they have with the same ID.
+const (
+	PushCreated = "created"
+	PushUpdated = "updated"
+)
+
+// NotificationPush is what goes over the socket: the notification itself
+// plus the push kind.
+type NotificationPush struct {
+	Event string `json:"event"`
+	Notification
+}
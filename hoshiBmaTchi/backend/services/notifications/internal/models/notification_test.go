@@ -0,0 +1,117 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/models"
+)
+
+func actor(id, name string) models.Actor {
+	return models.Actor{ID: id, Name: name, Image: id + ".png"}
+}
+
+func TestAddActor(t *testing.T) {
+	alice, bob, carol, dave := actor("a", "alice"), actor("b", "bob"), actor("c", "carol"), actor("d", "dave")
+
+	tests := []struct {
+		name        string
+		existing    models.Notification
+		add         []models.Actor
+		wantCount   int
+		wantActors  []string
+		wantSummary string
+	}{
+		{
+			name:        "first actor",
+			add:         []models.Actor{alice},
+			wantCount:   1,
+			wantActors:  []string{"a"},
+			wantSummary: "alice liked your post",
+		},
+		{
+			name:        "two actors",
+			add:         []models.Actor{alice, bob},
+			wantCount:   2,
+			wantActors:  []string{"b", "a"},
+			wantSummary: "bob and alice liked your post",
+		},
+		{
+			name:        "one more than named",
+			add:         []models.Actor{alice, bob, carol},
+			wantCount:   3,
+			wantActors:  []string{"c", "b", "a"},
+			wantSummary: "carol, bob and 1 other liked your post",
+		},
+		{
+			name:        "actors past the cap are counted, not kept",
+			add:         []models.Actor{alice, bob, carol, dave},
+			wantCount:   4,
+			wantActors:  []string{"d", "c", "b"},
+			wantSummary: "dave, carol and 2 others liked your post",
+		},
+		{
+			// Callers dedupe through NotificationActor before counting, so
+			// only the list is deduplicated here.
+			name:        "repeat actor moves to the front once",
+			add:         []models.Actor{alice, bob, alice},
+			wantCount:   3,
+			wantActors:  []string{"a", "b"},
+			wantSummary: "alice, bob and 1 other liked your post",
+		},
+		{
+			name:        "unnamed actor",
+			add:         []models.Actor{actor("x", "")},
+			wantCount:   1,
+			wantActors:  []string{"x"},
+			wantSummary: "Someone liked your post",
+		},
+		{
+			name: "counts carry over from stored notification",
+			existing: models.Notification{
+				ActorCount: 10,
+				Actors:     models.Actors{bob, carol, dave},
+			},
+			add:         []models.Actor{alice},
+			wantCount:   11,
+			wantActors:  []string{"a", "b", "c"},
+			wantSummary: "alice, bob and 9 others liked your post",
+		},
+		{
+			name: "stored sender is backfilled before the first new actor",
+			existing: models.Notification{
+				ActorCount: 1,
+				SenderID:   "b",
+				SenderName: "bob",
+			},
+			add:         []models.Actor{alice},
+			wantCount:   2,
+			wantActors:  []string{"a", "b"},
+			wantSummary: "alice and bob liked your post",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := tt.existing
+			n.Message = "liked your post"
+			n.BackfillActor()
+			for _, a := range tt.add {
+				n.AddActor(a)
+			}
+
+			last := tt.add[len(tt.add)-1]
+			assert.Equal(t, tt.wantCount, n.ActorCount)
+			assert.Equal(t, tt.wantSummary, n.Summary)
+			assert.Equal(t, last.ID, n.SenderID)
+			assert.Equal(t, last.Name, n.SenderName)
+			assert.Equal(t, last.Image, n.SenderImage)
+
+			ids := make([]string, 0, len(n.Actors))
+			for _, a := range n.Actors {
+				ids = append(ids, a.ID)
+			}
+			assert.Equal(t, tt.wantActors, ids)
+		})
+	}
+}
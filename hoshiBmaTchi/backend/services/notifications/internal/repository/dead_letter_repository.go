@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeadLetterRepository struct {
+	db *gorm.DB
+}
+
+func NewDeadLetterRepository(db *gorm.DB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+func (r *DeadLetterRepository) Create(letter *models.DeadLetter) error {
+	return r.db.Create(letter).Error
+}
+
+// List returns dead letters newest first, optionally only those from queue
+// and those older than beforeID.
+func (r *DeadLetterRepository) List(queue string, beforeID uint, limit int) ([]models.DeadLetter, error) {
+	q := r.db.Order("id desc").Limit(limit)
+	if queue != "" {
+		q = q.Where("queue = ?", queue)
+	}
+	if beforeID > 0 {
+		q = q.Where("id < ?", beforeID)
+	}
+	var letters []models.DeadLetter
+	err := q.Find(&letters).Error
+	return letters, err
+}
+
+func (r *DeadLetterRepository) Get(id uint) (*models.DeadLetter, error) {
+	var letter models.DeadLetter
+	if err := r.db.First(&letter, id).Error; err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+func (r *DeadLetterRepository) Delete(id uint) error {
+	return r.db.Delete(&models.DeadLetter{}, id).Error
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DigestRepository struct {
+	db *gorm.DB
+}
+
+func NewDigestRepository(db *gorm.DB) *DigestRepository {
+	return &DigestRepository{db: db}
+}
+
+// UnreadRecipients returns the users with unread notifications updated
+// since since.
+func (r *DigestRepository) UnreadRecipients(since time.Time) ([]string, error) {
+	var ids []string
+	err := r.db.Model(&models.Notification{}).
+		Where("is_read = ? AND updated_at >= ?", false, since).
+		Distinct().Pluck("recipient_id", &ids).Error
+	return ids, err
+}
+
+// UnreadSince returns the user's newest unread notifications updated since
+// since, up to limit, and how many there are in all.
+func (r *DigestRepository) UnreadSince(userID string, since time.Time, limit int) ([]models.Notification, int64, error) {
+	q := r.db.Model(&models.Notification{}).Where("recipient_id = ? AND is_read = ? AND updated_at >= ?", userID, false, since)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil || total == 0 {
+		return nil, total, err
+	}
+	var notifications []models.Notification
+	err := q.Order("updated_at desc").Limit(limit).Find(&notifications).Error
+	return notifications, total, err
+}
+
+// LastDigest returns when the user was last sent a digest, or the zero time
+// if never.
+func (r *DigestRepository) LastDigest(userID string) (time.Time, error) {
+	var log models.DigestLog
+	err := r.db.First(&log, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return time.Time{}, nil
+	}
+	return log.LastSentAt, err
+}
+
+// ClaimDigest moves the user's last digest from prev, as read by LastDigest,
+// to now. It reports false if another run got there first.
+func (r *DigestRepository) ClaimDigest(userID string, prev, now time.Time) (bool, error) {
+	var res *gorm.DB
+	if prev.IsZero() {
+		res = r.db.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.DigestLog{UserID: userID, LastSentAt: now})
+	} else {
+		res = r.db.Model(&models.DigestLog{}).
+			Where("user_id = ? AND last_sent_at = ?", userID, prev).
+			Update("last_sent_at", now)
+	}
+	return res.RowsAffected > 0, res.Error
+}
+
+// ReleaseDigest undoes a claim whose digest could not be sent, so the next
+// run tries again.
+func (r *DigestRepository) ReleaseDigest(userID string, prev, now time.Time) error {
+	q := r.db.Where("user_id = ? AND last_sent_at = ?", userID, now)
+	if prev.IsZero() {
+		return q.Delete(&models.DigestLog{}).Error
+	}
+	return q.Model(&models.DigestLog{}).Update("last_sent_at", prev).Error
+}
@@ -1,8 +1,23 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AggregateResult says what Aggregate did with a notification.
+type AggregateResult int
+
+const (
+	// Unchanged means the event was a redelivery or its actor was already
+	// counted.
+	Unchanged AggregateResult = iota
+	Created
+	Updated
 )
 
 type NotificationRepository struct {
@@ -17,9 +32,81 @@ func (r *NotificationRepository) Create(notification *models.Notification) error
 	return r.db.Create(notification).Error
 }
 
+// CreateOnce stores the notification unless one from the same event exists,
+// and reports whether it did.
+func (r *NotificationRepository) CreateOnce(notification *models.Notification) (bool, error) {
+	res := r.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).
+		Create(notification)
+	return res.RowsAffected > 0, res.Error
+}
+
+// Aggregate folds n into the recipient's notification of the same type about
+// the same entity created since since, or stores it as a new one. n must
+// carry a single actor as its sender; on return it holds the stored
+// notification.
+func (r *NotificationRepository) Aggregate(n *models.Notification, since time.Time) (AggregateResult, error) {
+	actor := models.Actor{ID: n.SenderID, Name: n.SenderName, Image: n.SenderImage}
+	result := Unchanged
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Serialise events for the same group, so two likes arriving together
+		// cannot both start a new notification.
+		key := n.RecipientID + "|" + n.Type + "|" + n.EntityID
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+			return err
+		}
+
+		var existing models.Notification
+		err := tx.Where("recipient_id = ? AND type = ? AND entity_id = ? AND created_at >= ?", n.RecipientID, n.Type, n.EntityID, since).
+			Order("created_at DESC").
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			n.Actors = nil
+			n.ActorCount = 0
+			n.AddActor(actor)
+			res := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "event_id"}}, DoNothing: true}).Create(n)
+			if res.Error != nil || res.RowsAffected == 0 {
+				return res.Error
+			}
+			result = Created
+			return tx.Create(&models.NotificationActor{NotificationID: n.ID, ActorID: actor.ID}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		*n = existing
+		// Notifications from before aggregation have no actor rows; record
+		// their sender first, or liking again would count them twice.
+		if legacy, ok := n.BackfillActor(); ok {
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.NotificationActor{NotificationID: existing.ID, ActorID: legacy.ID}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.NotificationActor{NotificationID: existing.ID, ActorID: actor.ID})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		n.AddActor(actor)
+		n.IsRead = false
+		result = Updated
+		return tx.Model(n).Select("sender_id", "sender_name", "sender_image", "actor_count", "actors", "summary", "is_read", "updated_at").
+			Updates(n).Error
+	})
+	if err != nil {
+		return Unchanged, err
+	}
+	return result, nil
+}
+
 func (r *NotificationRepository) GetByUserID(userID string) ([]models.Notification, error) {
 	var notifications []models.Notification
-	err := r.db.Where("recipient_id = ?", userID).Order("created_at desc").Limit(50).Find(&notifications).Error
+	err := r.db.Where("recipient_id = ?", userID).Order("updated_at desc").Limit(50).Find(&notifications).Error
 	return notifications, err
 }
 
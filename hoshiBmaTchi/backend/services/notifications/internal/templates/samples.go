@@ -0,0 +1,32 @@
+package templates
+
+// Samples holds example data for each template, for previews and tests.
+var Samples = map[string]map[string]interface{}{
+	"otp":                   {"code": "482913"},
+	"login_code":            {"code": "730156"},
+	"password_reset":        {"link": "http://localhost:5173/reset-password?token=example"},
+	"welcome":               {"name": "Alice"},
+	"verification_approved": {"name": "Alice"},
+	"report_update":         {"name": "Alice"},
+	"post_removed":          {},
+	"message_export": {
+		"link":    "http://localhost:9000/exports/example.zip",
+		"expires": "January 2, 2026 at 15:04 UTC",
+	},
+	"notification": {
+		"summary": "bob, carol and 3 others liked your post",
+		"link":    "http://localhost:5173/notifications",
+	},
+	"digest": {
+		"name":   "Alice",
+		"period": "daily",
+		"count":  12,
+		"more":   9,
+		"link":   "http://localhost:5173/notifications",
+		"items": []map[string]interface{}{
+			{"summary": "bob, carol and 3 others liked your post"},
+			{"summary": "dave commented on your post"},
+			{"summary": "erin started following you"},
+		},
+	},
+}
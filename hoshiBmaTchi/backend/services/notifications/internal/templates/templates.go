@@ -0,0 +1,222 @@
+// Package templates renders the emails the notifications service sends.
+//
+// Each template NAME has an HTML variant in files/NAME.html.tmpl and a text
+// variant in files/NAME.txt.tmpl, which also defines NAME.subject. Copy is
+// not written in the templates but looked up with {{t "key" args...}} in
+// locales/LOCALE.json, so a new language is a new JSON file. {{tn "key" n}}
+// picks "key.one" or "key.other" by n. Keys missing from a locale fall back
+// to DefaultLocale.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+const DefaultLocale = "en"
+
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+//go:embed files/*.tmpl
+var files embed.FS
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Email is a rendered template.
+type Email struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+type Renderer struct {
+	html     *htmltemplate.Template
+	text     *texttemplate.Template
+	names    []string
+	catalogs map[string]map[string]string
+}
+
+// New parses the embedded templates and locales.
+func New() (*Renderer, error) {
+	// Translation depends on the locale of each render, so the real "t",
+	// "tn" and "locale" are bound per render on a clone of these.
+	stubs := map[string]interface{}{
+		"t":      func(string, ...interface{}) string { return "" },
+		"tn":     func(string, interface{}) string { return "" },
+		"locale": func() string { return "" },
+		"dict":   dict,
+	}
+
+	html, err := htmltemplate.New("").Funcs(stubs).ParseFS(files, "files/*.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html templates: %w", err)
+	}
+	text, err := texttemplate.New("").Funcs(stubs).ParseFS(files, "files/*.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text templates: %w", err)
+	}
+
+	r := &Renderer{html: html, text: text, catalogs: make(map[string]map[string]string)}
+
+	entries, err := fs.ReadDir(files, "files")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".txt.tmpl")
+		if name == e.Name() {
+			continue
+		}
+		if html.Lookup(name+".html.tmpl") == nil {
+			return nil, fmt.Errorf("template %s has no html variant", name)
+		}
+		if text.Lookup(name+".subject") == nil {
+			return nil, fmt.Errorf("template %s does not define %s.subject", name, name)
+		}
+		r.names = append(r.names, name)
+	}
+	sort.Strings(r.names)
+
+	catalogFiles, err := fs.ReadDir(locales, "locales")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range catalogFiles {
+		b, err := locales.ReadFile(path.Join("locales", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		catalog := make(map[string]string)
+		if err := json.Unmarshal(b, &catalog); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", e.Name(), err)
+		}
+		r.catalogs[strings.TrimSuffix(e.Name(), ".json")] = catalog
+	}
+	if _, ok := r.catalogs[DefaultLocale]; !ok {
+		return nil, fmt.Errorf("no %s catalog", DefaultLocale)
+	}
+	return r, nil
+}
+
+// Names lists the templates, sorted.
+func (r *Renderer) Names() []string {
+	return r.names
+}
+
+// Locales lists the languages templates can be rendered in, sorted.
+func (r *Renderer) Locales() []string {
+	out := make([]string, 0, len(r.catalogs))
+	for l := range r.catalogs {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Render renders the named template in locale, which may be a language tag
+// such as "id-ID"; unsupported locales get DefaultLocale.
+func (r *Renderer) Render(name, locale string, data map[string]interface{}) (*Email, error) {
+	if r.text.Lookup(name+".subject") == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTemplate, name)
+	}
+	locale = r.resolveLocale(locale)
+	funcs := r.funcs(locale)
+
+	html, err := r.html.Clone()
+	if err != nil {
+		return nil, err
+	}
+	text, err := r.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	html.Funcs(funcs)
+	text.Funcs(funcs)
+
+	var subject, htmlBody, textBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, name+".subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	if err := html.ExecuteTemplate(&htmlBody, name+".html.tmpl", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", name, err)
+	}
+	if err := text.ExecuteTemplate(&textBody, name+".txt.tmpl", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", name, err)
+	}
+
+	return &Email{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    htmlBody.String(),
+		Text:    strings.TrimSpace(textBody.String()) + "\n",
+	}, nil
+}
+
+func (r *Renderer) resolveLocale(locale string) string {
+	locale = strings.ToLower(locale)
+	if _, ok := r.catalogs[locale]; ok {
+		return locale
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		if _, ok := r.catalogs[locale[:i]]; ok {
+			return locale[:i]
+		}
+	}
+	return DefaultLocale
+}
+
+func (r *Renderer) funcs(locale string) map[string]interface{} {
+	lookup := func(key string) string {
+		if s, ok := r.catalogs[locale][key]; ok {
+			return s
+		}
+		if s, ok := r.catalogs[DefaultLocale][key]; ok {
+			return s
+		}
+		return key
+	}
+	t := func(key string, args ...interface{}) string {
+		s := lookup(key)
+		// Singular forms usually spell the number out, e.g. "1 more".
+		if len(args) == 0 || !strings.Contains(s, "%") {
+			return s
+		}
+		return fmt.Sprintf(s, args...)
+	}
+	return map[string]interface{}{
+		"t": t,
+		"tn": func(key string, n interface{}) string {
+			if fmt.Sprint(n) == "1" {
+				return t(key+".one", n)
+			}
+			return t(key+".other", n)
+		},
+		"locale": func() string { return locale },
+	}
+}
+
+// dict builds a map from alternating keys and values, for passing several
+// values to a nested template.
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict needs key and value pairs")
+	}
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, errors.New("dict keys must be strings")
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
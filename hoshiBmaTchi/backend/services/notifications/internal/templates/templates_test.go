@@ -0,0 +1,97 @@
+package templates_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Hinsane5/hoshiBmaTchi/backend/services/notifications/internal/templates"
+)
+
+// rawKey matches a catalog key that was printed instead of its translation.
+var rawKey = regexp.MustCompile(`\b(footer|greeting|[a-z_]+\.[a-z_]+(\.one|\.other)?)\b`)
+
+func TestRender_EveryTemplateInEveryLocale(t *testing.T) {
+	r, err := templates.New()
+	require.NoError(t, err)
+	require.NotEmpty(t, r.Names())
+	assert.Contains(t, r.Locales(), "id")
+
+	for _, name := range r.Names() {
+		data, ok := templates.Samples[name]
+		require.True(t, ok, "no sample data for %s", name)
+
+		for _, locale := range r.Locales() {
+			email, err := r.Render(name, locale, data)
+			require.NoError(t, err, "%s/%s", name, locale)
+
+			assert.NotEmpty(t, email.Subject, "%s/%s", name, locale)
+			assert.NotContains(t, email.Subject, "\n")
+			assert.Contains(t, email.HTML, `<html lang="`+locale+`">`)
+			for _, body := range []string{email.Subject, stripURLs(email.Text), stripURLs(email.HTML)} {
+				assert.NotRegexp(t, rawKey, body, "%s/%s has an untranslated key", name, locale)
+				assert.NotContains(t, body, "%!", "%s/%s has a bad format verb", name, locale)
+				assert.NotContains(t, body, "<no value>", "%s/%s reads missing data", name, locale)
+			}
+		}
+	}
+}
+
+func TestRender_Plurals(t *testing.T) {
+	r, err := templates.New()
+	require.NoError(t, err)
+
+	one, err := r.Render("digest", "en", map[string]interface{}{
+		"name": "Alice", "period": "weekly", "count": 1, "more": 0, "link": "http://x",
+		"items": []map[string]interface{}{{"summary": "bob liked your post"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "You have 1 unread notification", one.Subject)
+	assert.Contains(t, one.Text, "this week")
+	assert.NotContains(t, one.Text, "more.")
+
+	many, err := r.Render("digest", "en", templates.Samples["digest"])
+	require.NoError(t, err)
+	assert.Equal(t, "You have 12 unread notifications", many.Subject)
+	assert.Contains(t, many.Text, "And 9 more.")
+}
+
+func TestRender_LocaleFallback(t *testing.T) {
+	r, err := templates.New()
+	require.NoError(t, err)
+
+	id, err := r.Render("welcome", "id-ID", templates.Samples["welcome"])
+	require.NoError(t, err)
+	assert.Contains(t, id.HTML, `lang="id"`)
+
+	en, err := r.Render("welcome", "fr", templates.Samples["welcome"])
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to hoshiBmaTchi!", en.Subject)
+}
+
+func TestRender_EscapesHTML(t *testing.T) {
+	r, err := templates.New()
+	require.NoError(t, err)
+
+	email, err := r.Render("welcome", "en", map[string]interface{}{"name": "<script>alert(1)</script>"})
+	require.NoError(t, err)
+	assert.NotContains(t, email.HTML, "<script>")
+	assert.Contains(t, email.Text, "<script>")
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	r, err := templates.New()
+	require.NoError(t, err)
+
+	_, err = r.Render("nope", "en", nil)
+	assert.ErrorIs(t, err, templates.ErrUnknownTemplate)
+}
+
+var urlPattern = regexp.MustCompile(`https?://[^"\s<]+|[a-z-]+:[^;"]+;`)
+
+// stripURLs drops links and inline styles, whose dots would look like keys.
+func stripURLs(html string) string {
+	return urlPattern.ReplaceAllString(html, "")
+}
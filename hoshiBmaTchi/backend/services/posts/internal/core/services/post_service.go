@@ -2,18 +2,17 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"regexp"
 	"strings"
 
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/posts"
 	userPb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/users"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/posts/internal/core/domain"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/posts/internal/core/ports"
 	"github.com/google/uuid"
-	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 var mentionRegex = regexp.MustCompile(`@([a-zA-Z0-9._]+)`)
@@ -21,24 +20,14 @@ var hashtagRegex = regexp.MustCompile(`#([a-zA-Z0-9_]+)`)
 
 type PostService struct {
 	repo       ports.PostRepository
-	amqpChan   *amqp.Channel
+	events     *events.Publisher
 	userClient userPb.UserServiceClient
 }
 
-type NotificationEvent struct {
-	RecipientID string   `json:"recipient_id"`
-	SenderID    string   `json:"sender_id"`
-	SenderName  string `json:"sender_name"` 
-	SenderImage string `json:"sender_image"` 
-	Type        string `json:"type"`
-	EntityID    string   `json:"entity_id"`
-	Message     string `json:"message"`
-}
-
-func NewPostService(repo ports.PostRepository, amqpChan *amqp.Channel, userClient userPb.UserServiceClient) *PostService {
+func NewPostService(repo ports.PostRepository, publisher *events.Publisher, userClient userPb.UserServiceClient) *PostService {
 	return &PostService{
 		repo:       repo,
-		amqpChan:   amqpChan,
+		events:     publisher,
 		userClient: userClient,
 	}
 }
@@ -119,12 +108,12 @@ func (s *PostService) CreatePost(ctx context.Context, req *pb.CreatePostRequest)
 		}
 
 		for _, mention := range mentions {
-			event := NotificationEvent{
+			event := events.NotificationEvent{
 				RecipientID: mention.MentionedUserID.String(),
 				SenderID:    req.UserId,
 				SenderName:  senderProfile.Username,
 				SenderImage: senderProfile.ProfilePictureUrl,
-				Type:        "mention",
+				Type:        events.TypeMention,
 				EntityID:    post.ID.String(),
 				Message:     "mentioned you in a post",
 			}
@@ -148,27 +137,9 @@ func (s *PostService) GetExplorePosts(ctx context.Context, limit, offset int, ha
     return s.repo.GetExplorePosts(ctx, limit, offset, hashtag)
 }
 
-func (s *PostService) publishNotification(event NotificationEvent) {
-	body, _ := json.Marshal(event)
-
-	routingKey := fmt.Sprintf("notification.%s", event.Type)
-
-	log.Printf("[DEBUG] Publishing to RabbitMQ. Key: %s, Recipient: %s", routingKey, event.RecipientID)
-
-	err := s.amqpChan.PublishWithContext(context.Background(),
-		"notification_exchange",
-		routingKey, 
-		false, false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
-	)
-	
-	if err != nil {
-		log.Printf("Failed to publish notification: %v", err)
-	} else {
-		log.Printf("[DEBUG] Successfully published to 'notification_exchange'")
+func (s *PostService) publishNotification(event events.NotificationEvent) {
+	if err := s.events.Publish(context.Background(), event); err != nil {
+		log.Printf("Failed to publish %s notification for %s: %v", event.Type, event.RecipientID, err)
 	}
 }
 
@@ -203,12 +174,12 @@ func (s *PostService) LikePost(ctx context.Context, req *pb.LikePostRequest) err
 
 		log.Printf("[DEBUG] Liker Profile Fetched. Preparing event...")
 
-        event := NotificationEvent{
+        event := events.NotificationEvent{
             RecipientID: post.UserID.String(),
             SenderID:    req.UserId,          
             SenderName:  likerProfile.Username,
             SenderImage: likerProfile.ProfilePictureUrl,
-            Type:        "like",
+            Type:        events.TypeLike,
             EntityID:    post.ID.String(),    
             Message:     "liked your post",
         }
@@ -247,12 +218,12 @@ func (s *PostService) CreateComment(ctx context.Context, req *pb.CreateCommentRe
         senderProfile, err := s.userClient.GetUserProfile(context.Background(), &userPb.GetUserProfileRequest{UserId: req.UserId})
         if err != nil { return }
 
-        event := NotificationEvent{
+        event := events.NotificationEvent{
             RecipientID: post.UserID.String(),
             SenderID:    req.UserId,
             SenderName:  senderProfile.Username,
             SenderImage: senderProfile.ProfilePictureUrl,
-            Type:        "comment",
+            Type:        events.TypeComment,
             EntityID:    post.ID.String(),
             Message:     "commented on your post",
         }
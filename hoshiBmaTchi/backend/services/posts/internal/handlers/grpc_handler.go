@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	// "errors"
@@ -12,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/posts"
 	userPb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/users"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/posts/internal/core/domain"
@@ -21,7 +21,6 @@ import (
 
 	// "github.com/jackc/pgx/v5/pgconn"
 	"github.com/minio/minio-go/v7"
-	amqp "github.com/rabbitmq/amqp091-go"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -35,7 +34,7 @@ type Server struct {
 	bucketName     string  
 	publicEndPoint string    
 	userClient     userPb.UserServiceClient     
-	amqpChan       *amqp.Channel
+	events         *events.Publisher
 }
 
 func NewGRPCServer(
@@ -46,7 +45,7 @@ func NewGRPCServer(
     bucketName string, 
     publicEndPoint string, 
     userClient userPb.UserServiceClient,
-    amqpChan *amqp.Channel, 
+    publisher *events.Publisher,
 ) *Server {
 	return &Server{
 		repo:           repo,
@@ -56,7 +55,7 @@ func NewGRPCServer(
 		bucketName:     bucketName,
 		publicEndPoint: publicEndPoint,
 		userClient:     userClient,
-        amqpChan:       amqpChan, 
+        events:         publisher,
 	}
 }
 
@@ -726,26 +725,12 @@ func (s *Server) ReviewPostReport(ctx context.Context, req *pb.ReviewReportReque
 	if req.Action == "DELETE_POST" {
         emailRes, err := s.userClient.GetUserEmail(ctx, &userPb.GetUserEmailRequest{UserId: report.ReporterID.String()})
         if err == nil && emailRes.Email != "" {
-            type EmailTask struct {
-                Email   string `json:"email"`
-                Subject string `json:"subject"`
-                Body    string `json:"body"`
+            task := events.EmailTask{
+                Email:    emailRes.Email,
+                Template: events.TemplatePostRemoved,
+                Locale:   emailRes.Locale,
             }
-            emailBody := "We have reviewed your report and removed the content that violated our guidelines."
-            task := EmailTask{Email: emailRes.Email, Subject: "Report Action Taken", Body: emailBody}
-            taskBody, _ := json.Marshal(task)
-
-			err = s.amqpChan.PublishWithContext(ctx, 
-                "email_exchange", 
-                "send_email", 
-                false, 
-                false, 
-                amqp.Publishing{
-                    ContentType: "application/json", 
-                    Body: taskBody,
-                })
-            
-            if err != nil {
+            if err := s.events.SendEmail(ctx, task); err != nil {
                 log.Printf("Failed to publish email task: %v", err)
             }
         }
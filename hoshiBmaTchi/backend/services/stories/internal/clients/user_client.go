@@ -73,6 +73,19 @@ func (c *UserServiceClient) GetFollowing(ctx context.Context, userID string) ([]
     return followingIDs, nil
 }
 
+// GetUserProfile returns a user's public profile, used to show who a story
+// notification is from.
+func (c *UserServiceClient) GetUserProfile(ctx context.Context, userID string) (*pb.GetUserProfileResponse, error) {
+	if c == nil || c.client == nil {
+		return nil, fmt.Errorf("user service client not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return c.client.GetUserProfile(ctx, &pb.GetUserProfileRequest{UserId: userID})
+}
+
 func (c *UserServiceClient) Close() error {
 	if c.conn != nil {
 		log.Println("Closing user service connection")
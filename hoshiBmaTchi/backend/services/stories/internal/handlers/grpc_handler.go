@@ -8,11 +8,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/stories"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/stories/internal/clients"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/stories/internal/core/domain"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/stories/internal/core/ports"
-	"github.com/Hinsane5/hoshiBmaTchi/backend/services/stories/internal/events"
 	"github.com/google/uuid"
 	"github.com/minio/minio-go/v7"
 	"google.golang.org/grpc/codes"
@@ -26,7 +26,7 @@ type GRPCHandler struct {
 	redisRepo         ports.RedisRepository
 	userServiceClient *clients.UserServiceClient
 	chatServiceClient *clients.ChatServiceClient 
-	publisher         *events.EventPublisher
+	publisher         *events.Publisher
 	minioClient *minio.Client
 	presignClient     *minio.Client
     bucketName  string
@@ -37,7 +37,7 @@ func NewGRPCHandler(
 	redisRepo ports.RedisRepository,
 	userClient *clients.UserServiceClient,
 	chatClient *clients.ChatServiceClient,
-	publisher *events.EventPublisher,
+	publisher *events.Publisher,
 	minioClient *minio.Client,
 	presignClient *minio.Client,
     bucketName string,
@@ -257,24 +257,33 @@ func (h *GRPCHandler) LikeStory(ctx context.Context, req *pb.LikeStoryRequest) (
 	}
 
 	if story.UserID != req.UserId && h.publisher != nil {
-		go func() {
-			err := h.publisher.PublishNotification(context.Background(), events.NotificationEvent{
-				Type:       "story_like",
-				ActorID:    req.UserId,
-				TargetID:   story.UserID,
-				ResourceID: story.ID,
-				Message:    "liked your story.",
-				CreatedAt:  time.Now(),
-			})
-			if err != nil {
-				log.Printf("Failed to publish notification: %v", err)
-			}
-		}()
+		go h.notify(events.NotificationEvent{
+			Type:        events.TypeStoryLike,
+			RecipientID: story.UserID,
+			SenderID:    req.UserId,
+			EntityID:    story.ID,
+			Message:     "liked your story.",
+		})
 	}
 
 	return &pb.LikeStoryResponse{Success: true}, nil
 }
 
+// notify fills in who the notification is from and publishes it.
+func (h *GRPCHandler) notify(event events.NotificationEvent) {
+	ctx := context.Background()
+	if sender, err := h.userServiceClient.GetUserProfile(ctx, event.SenderID); err == nil {
+		event.SenderName = sender.Username
+		event.SenderImage = sender.ProfilePictureUrl
+	} else {
+		log.Printf("Failed to load profile of %s for notification: %v", event.SenderID, err)
+	}
+
+	if err := h.publisher.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish %s notification: %v", event.Type, err)
+	}
+}
+
 func (h *GRPCHandler) UnlikeStory(ctx context.Context, req *pb.UnlikeStoryRequest) (*pb.UnlikeStoryResponse, error) {
 	if err := h.repo.UnlikeStory(ctx, req.StoryId, req.UserId); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to unlike story: %v", err)
@@ -301,19 +310,13 @@ func (h *GRPCHandler) ReplyToStory(ctx context.Context, req *pb.ReplyToStoryRequ
 	}
 
 	if story.UserID != req.UserId && h.publisher != nil {
-		go func() {
-			err := h.publisher.PublishNotification(context.Background(), events.NotificationEvent{
-				Type:       "story_reply",
-				ActorID:    req.UserId,
-				TargetID:   story.UserID,
-				ResourceID: story.ID,
-				Message:    fmt.Sprintf("replied to your story: %s", req.Content),
-				CreatedAt:  time.Now(),
-			})
-			if err != nil {
-				log.Printf("Failed to publish notification: %v", err)
-			}
-		}()
+		go h.notify(events.NotificationEvent{
+			Type:        events.TypeStoryReply,
+			RecipientID: story.UserID,
+			SenderID:    req.UserId,
+			EntityID:    story.ID,
+			Message:     fmt.Sprintf("replied to your story: %s", req.Content),
+		})
 	}
 
 	return &pb.ReplyToStoryResponse{
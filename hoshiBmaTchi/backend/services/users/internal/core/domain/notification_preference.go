@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Channels a kind of notification can be delivered on.
+const (
+	ChannelInApp = "in_app"
+	ChannelEmail = "email"
+	ChannelOff   = "off"
+)
+
+// How often unread notifications are emailed as a digest.
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// NotificationPreference holds a user's channel per kind of notification.
+// Users without a row get everything in-app.
+type NotificationPreference struct {
+	UserID       uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Likes        string    `gorm:"not null;default:'in_app'"`
+	Comments     string    `gorm:"not null;default:'in_app'"`
+	Follows      string    `gorm:"not null;default:'in_app'"`
+	Mentions     string    `gorm:"not null;default:'in_app'"`
+	StoryReplies string    `gorm:"not null;default:'in_app'"`
+	Messages     string    `gorm:"not null;default:'in_app'"`
+	Digest       string    `gorm:"not null;default:'off'"`
+	UpdatedAt    time.Time
+}
+
+func DefaultNotificationPreference(userID uuid.UUID) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:       userID,
+		Likes:        ChannelInApp,
+		Comments:     ChannelInApp,
+		Follows:      ChannelInApp,
+		Mentions:     ChannelInApp,
+		StoryReplies: ChannelInApp,
+		Messages:     ChannelInApp,
+		Digest:       DigestOff,
+	}
+}
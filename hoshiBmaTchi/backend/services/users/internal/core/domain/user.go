@@ -50,6 +50,10 @@ type User struct{
 
 	IsPrivate       bool `gorm:"default:false"`
 
+	// Locale is the language account emails go out in, e.g. "id". Empty
+	// means the default.
+	Locale string `gorm:"size:16"`
+
 	CloseFriends []CloseFriend `gorm:"foreignKey:UserID"`
     HiddenStoryViewers []HiddenStoryViewer `gorm:"foreignKey:UserID"`
     VerificationRequests []VerificationRequest `gorm:"foreignKey:UserID"`
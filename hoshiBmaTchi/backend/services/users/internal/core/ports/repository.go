@@ -12,6 +12,7 @@ type UserRepository interface{
 	FindByEmail(email string) (*domain.User, error)
 	FindByEmailOrUsername(identifier string) (*domain.User, error)
 	FindByID(userID string) (*domain.User, error)
+	FindByIDs(userIDs []string) ([]*domain.User, error)
 	UpdatePassword(userID string, newPassword string) error
 	GetUserProfileWithStats(userID string) (*domain.User, int64, int64, error)
 	CreateFollow(followerID, followingID string) error
@@ -25,7 +26,10 @@ type UserRepository interface{
     DeleteBlock(blockerID, blockedID string) error
     GetBlockedUsers(userID string) ([]*domain.User, error)
     IsBlocked(userA, userB string) (bool, error)
+    GetBlockRelationIDs(userID string) ([]string, []string, error)
 	UpdateUser(user *domain.User) error
+	GetNotificationPreference(userID uuid.UUID) (*domain.NotificationPreference, error)
+	SaveNotificationPreference(pref *domain.NotificationPreference) error
 	
 	AddCloseFriend(userID, targetID uuid.UUID) error
 	RemoveCloseFriend(userID, targetID uuid.UUID) error
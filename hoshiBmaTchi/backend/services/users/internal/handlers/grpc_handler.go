@@ -16,13 +16,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Hinsane5/hoshiBmaTchi/backend/proto/events"
 	pb "github.com/Hinsane5/hoshiBmaTchi/backend/proto/users"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/users/internal/core/domain"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/users/internal/core/ports"
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/users/internal/core/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/api/idtoken"
 	"google.golang.org/grpc/codes"
@@ -36,37 +36,37 @@ type UserHandler struct{
 	pb.UnimplementedUserServiceServer
 	repo ports.UserRepository
 	redis *redis.Client
-	amqpChan *amqp.Channel
+	events *events.Publisher
 }
 
 type turnstileResponse struct{
 	Success bool `json:"success"`
 }
 
-type EmailTask struct {
-	Email   string `json:"email"`
-	Subject string `json:"subject"`
-	Body    string `json:"body"`
-}
-
-type NotificationEvent struct {
-	RecipientID string `json:"recipient_id"`
-	SenderID    string `json:"sender_id"`
-	SenderName  string `json:"sender_name"`
-	SenderImage string `json:"sender_image"`
-	Type        string `json:"type"`
-	EntityID    string `json:"entity_id"`
-	Message     string `json:"message"`
-}
-
-func NewUserHandler(repo ports.UserRepository, redis *redis.Client, amqpChan *amqp.Channel) *UserHandler{
+func NewUserHandler(repo ports.UserRepository, redis *redis.Client, publisher *events.Publisher) *UserHandler{
 	return &UserHandler{
 		repo: repo,
 		redis: redis,
-		amqpChan: amqpChan,
+		events: publisher,
 	}
 }
 
+// maxLocaleLength is the size of the users.locale column.
+const maxLocaleLength = 16
+
+// normalizeLocale turns a client's language, either a tag such as "id-ID" or
+// an Accept-Language header, into the tag stored on the account. Anything
+// unusable becomes "", which emails read as the default language.
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ",")
+	locale, _, _ = strings.Cut(locale, ";")
+	locale = strings.TrimSpace(locale)
+	if locale == "*" || len(locale) > maxLocaleLength {
+		return ""
+	}
+	return locale
+}
+
 func (h *UserHandler) SendOtp(ctx context.Context, req *pb.SendOtpRequest) (*pb.SendOtpResponse, error){
 	if !regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.com$`).MatchString(req.Email) {
 		return nil, status.Error(codes.InvalidArgument, "Invalid email format")
@@ -92,22 +92,14 @@ func (h *UserHandler) SendOtp(ctx context.Context, req *pb.SendOtpRequest) (*pb.
 		return nil, status.Error(codes.Internal, "failed to store OTP")
 	}
 
-	emailBody := fmt.Sprintf("Your verification code is %s", otp)
-	task := EmailTask{Email: req.Email, Subject: "Verify your email", Body: emailBody}
-	taskBody, _ := json.Marshal(task)
-
-	err = h.amqpChan.PublishWithContext(ctx, 
-		"email_exchange",
-		"send_email",
-		false,
-		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        taskBody,
-		},
-	)
-
-	if err != nil {
+	task := events.EmailTask{
+		Email:    req.Email,
+		Template: events.TemplateOTP,
+		Data:     map[string]interface{}{"code": otp},
+		Locale:   normalizeLocale(req.Locale),
+	}
+	if err := h.events.SendEmail(ctx, task); err != nil {
+		log.Printf("Failed to queue OTP email: %v", err)
 		return nil, status.Error(codes.Internal, "failed to publish email task")
 	}
 
@@ -277,20 +269,14 @@ func (h *UserHandler) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 			return nil, status.Error(codes.Internal, "failed to store 2FA code")
 		}
 
-		emailBody := fmt.Sprintf("Your 2FA login code is: %s It expires in 5 minutes.", otp)
-		task := EmailTask{Email: user.Email, Subject: "Your Login Code", Body: emailBody}
-		taskBody, _ := json.Marshal(task)
-
-		err = h.amqpChan.PublishWithContext(ctx,
-			"email_exchange",
-			"send_email",
-			false, false,
-			amqp.Publishing{
-				ContentType: "application/json",
-				Body:        taskBody,
-			},
-		)
-		if err != nil {
+		task := events.EmailTask{
+			Email:    user.Email,
+			Template: events.TemplateLoginCode,
+			Data:     map[string]interface{}{"code": otp},
+			Locale:   user.Locale,
+		}
+		if err := h.events.SendEmail(ctx, task); err != nil {
+			log.Printf("Failed to queue 2FA email for %s: %v", user.ID, err)
 			return nil, status.Error(codes.Internal, "failed to publish 2FA email")
 		}
 
@@ -380,22 +366,15 @@ func (h *UserHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestP
 		frontendURL = "http://localhost:5173"
 	}
 
-	resetLink := fmt.Sprintf("http://localhost:5173/reset-password?token=%s", resetToken)
-	emailBody := fmt.Sprintf("Click here to reset your password: <a href=\"%s\">%s</a>. This link expires in 15 minutes.", resetLink, resetLink)
-	
-	task := EmailTask{Email: user.Email, Subject: "Reset Your Password", Body: emailBody}
-	taskBody, _ := json.Marshal(task)
-
-	err = h.amqpChan.PublishWithContext(ctx,
-		"email_exchange",
-		"send_email", 
-		false, false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        taskBody,
-		},
-	)
-	if err != nil {
+	resetLink := fmt.Sprintf("%s/reset-password?token=%s", frontendURL, resetToken)
+	task := events.EmailTask{
+		Email:    user.Email,
+		Template: events.TemplatePasswordReset,
+		Data:     map[string]interface{}{"link": resetLink},
+		Locale:   user.Locale,
+	}
+	if err := h.events.SendEmail(ctx, task); err != nil {
+		log.Printf("Failed to queue password reset email for %s: %v", user.ID, err)
 		return nil, status.Error(codes.Internal, "failed to publish reset email")
 	}
 
@@ -491,6 +470,7 @@ func (h *UserHandler) RegisterUser(ctx context.Context, req *pb.RegisterUserRequ
 		ProfilePictureURL: req.ProfilePictureUrl,
 		SubscribedToNewsletter: req.SubscribeToNewsletter,
 		TwoFactorEnabled:      req.Enable_2Fa,
+		Locale:                normalizeLocale(req.Locale),
 	}
 
 	err = h.repo.Save(newUser)
@@ -506,22 +486,13 @@ func (h *UserHandler) RegisterUser(ctx context.Context, req *pb.RegisterUserRequ
 
 	h.redis.Del(ctx, otpKey)
 
-	welcomeBody := fmt.Sprintf("Hi %s, welcome to hoshiBmaTchi!", req.Name)
-	task := EmailTask{Email: req.Email, Subject: "Welcome to hoshiBmaTchi!", Body: welcomeBody}
-	taskBody, _ := json.Marshal(task)
-
-	err = h.amqpChan.PublishWithContext(ctx, 
-		"email_exchange",
-		"email.welcome",
-		false, 
-		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:       taskBody,
-		},
-	)	
-
-	if err != nil{
+	task := events.EmailTask{
+		Email:    req.Email,
+		Template: events.TemplateWelcome,
+		Data:     map[string]interface{}{"name": req.Name},
+		Locale:   newUser.Locale,
+	}
+	if err := h.events.SendEmail(ctx, task); err != nil {
 		log.Printf("Failed to publish welcome email task: %v", err)
 	}
 
@@ -630,6 +601,40 @@ func (h *UserHandler) GetUserProfile(ctx context.Context, req *pb.GetUserProfile
     }, nil
 }
 
+// maxBulkProfiles caps GetUserProfiles so one call cannot scan the table.
+const maxBulkProfiles = 500
+
+// GetUserProfiles resolves many users in one round trip, for lists such as
+// chat's conversation list. Blocks are not applied; callers that care filter
+// the result themselves.
+func (h *UserHandler) GetUserProfiles(ctx context.Context, req *pb.GetUserProfilesRequest) (*pb.GetUserProfilesResponse, error) {
+	if len(req.UserIds) > maxBulkProfiles {
+		return nil, status.Errorf(codes.InvalidArgument, "At most %d user IDs can be requested at once", maxBulkProfiles)
+	}
+	if len(req.UserIds) == 0 {
+		return &pb.GetUserProfilesResponse{}, nil
+	}
+
+	users, err := h.repo.FindByIDs(req.UserIds)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to fetch user profiles")
+	}
+
+	profiles := make([]*pb.UserProfile, 0, len(users))
+	for _, u := range users {
+		profiles = append(profiles, &pb.UserProfile{
+			UserId:            u.ID.String(),
+			Username:          u.Username,
+			Name:              u.Name,
+			ProfilePictureUrl: h.sanitizeAvatarURL(u.ProfilePictureURL),
+			IsBanned:          u.IsBanned,
+			IsVerified:        u.IsVerified,
+		})
+	}
+
+	return &pb.GetUserProfilesResponse{Users: profiles}, nil
+}
+
 func (h *UserHandler) FollowUser(ctx context.Context, req *pb.FollowUserRequest) (*pb.FollowUserResponse, error) {
     if req.FollowerId == req.FollowingId {
         return nil, status.Error(codes.InvalidArgument, "You cannot follow yourself")
@@ -649,26 +654,18 @@ func (h *UserHandler) FollowUser(ctx context.Context, req *pb.FollowUserRequest)
         follower, _, _, err := h.repo.GetUserProfileWithStats(req.FollowerId)
         if err != nil { return }
 
-        event := NotificationEvent{
-            RecipientID: req.FollowingId,       
-            SenderID:    req.FollowerId,        
+        event := events.NotificationEvent{
+            RecipientID: req.FollowingId,
+            SenderID:    req.FollowerId,
             SenderName:  follower.Username,
-            SenderImage: follower.ProfilePictureURL,
-            Type:        "follow",
-            EntityID:    req.FollowerId,        
+            SenderImage: h.sanitizeAvatarURL(follower.ProfilePictureURL),
+            Type:        events.TypeFollow,
+            EntityID:    req.FollowerId,
             Message:     "started following you",
         }
-
-        body, _ := json.Marshal(event)
-        h.amqpChan.PublishWithContext(context.Background(),
-            "notification_exchange", 
-            "notification.event",    
-            false, false,
-            amqp.Publishing{
-                ContentType: "application/json",
-                Body:        body,
-            },
-        )
+        if err := h.events.Publish(context.Background(), event); err != nil {
+            log.Printf("Failed to publish follow notification: %v", err)
+        }
     }()
 
     return &pb.FollowUserResponse{Message: "Successfully followed user"}, nil
@@ -941,19 +938,132 @@ func (h *UserHandler) GetBlockedList(ctx context.Context, req *pb.GetBlockedList
     return &pb.GetBlockedListResponse{Users: responseUsers}, nil
 }
 
+func (h *UserHandler) IsBlocked(ctx context.Context, req *pb.IsBlockedRequest) (*pb.IsBlockedResponse, error) {
+    if req.UserId == "" || req.TargetId == "" {
+        return nil, status.Error(codes.InvalidArgument, "User ID and target ID required")
+    }
+
+    blocked, err := h.repo.IsBlocked(req.UserId, req.TargetId)
+    if err != nil {
+        return nil, status.Error(codes.Internal, "Failed to check block status")
+    }
+
+    return &pb.IsBlockedResponse{IsBlocked: blocked}, nil
+}
+
+func (h *UserHandler) GetBlockRelations(ctx context.Context, req *pb.GetBlockRelationsRequest) (*pb.GetBlockRelationsResponse, error) {
+    if req.UserId == "" {
+        return nil, status.Error(codes.InvalidArgument, "User ID required")
+    }
+
+    blocked, blockedBy, err := h.repo.GetBlockRelationIDs(req.UserId)
+    if err != nil {
+        return nil, status.Error(codes.Internal, "Failed to fetch block relations")
+    }
+
+    return &pb.GetBlockRelationsResponse{BlockedIds: blocked, BlockedByIds: blockedBy}, nil
+}
+
+// GetRelationship describes how two users are connected. Chat uses it to
+// decide whether a new conversation lands in the inbox or in message requests.
+func (h *UserHandler) GetRelationship(ctx context.Context, req *pb.GetRelationshipRequest) (*pb.GetRelationshipResponse, error) {
+    if req.UserId == "" || req.TargetId == "" {
+        return nil, status.Error(codes.InvalidArgument, "User ID and target ID required")
+    }
+
+    target, err := h.repo.FindByID(req.TargetId)
+    if err != nil {
+        return nil, status.Error(codes.NotFound, "User not found")
+    }
+
+    following, err := h.repo.IsFollowing(req.UserId, req.TargetId)
+    if err != nil {
+        return nil, status.Error(codes.Internal, "Failed to check follow status")
+    }
+    followedBy, err := h.repo.IsFollowing(req.TargetId, req.UserId)
+    if err != nil {
+        return nil, status.Error(codes.Internal, "Failed to check follow status")
+    }
+
+    return &pb.GetRelationshipResponse{
+        IsFollowing:     following,
+        IsFollowedBy:    followedBy,
+        TargetIsPrivate: target.IsPrivate,
+    }, nil
+}
+
 func (h *UserHandler) GetSettings(ctx context.Context, req *pb.GetSettingsRequest) (*pb.GetSettingsResponse, error) {
     user, err := h.repo.FindByID(req.UserId)
     if err != nil {
         return nil, status.Error(codes.Internal, "Failed to fetch user settings")
     }
 
+    pref, err := h.repo.GetNotificationPreference(user.ID)
+    if err != nil {
+        return nil, status.Error(codes.Internal, "Failed to fetch user settings")
+    }
+
     return &pb.GetSettingsResponse{
         IsPrivate:   user.IsPrivate,            
         EnablePush:  user.PushNotificationsEnabled,    
         EnableEmail: user.SubscribedToNewsletter, 
+        Locale:      user.Locale,
+        NotificationPreferences: &pb.NotificationPreferences{
+            Likes:        channelToProto(pref.Likes),
+            Comments:     channelToProto(pref.Comments),
+            Follows:      channelToProto(pref.Follows),
+            Mentions:     channelToProto(pref.Mentions),
+            StoryReplies: channelToProto(pref.StoryReplies),
+            Messages:     channelToProto(pref.Messages),
+            Digest:       digestToProto(pref.Digest),
+        },
     }, nil
 }
 
+func channelToProto(channel string) pb.NotificationChannel {
+    switch channel {
+    case domain.ChannelEmail:
+        return pb.NotificationChannel_CHANNEL_EMAIL
+    case domain.ChannelOff:
+        return pb.NotificationChannel_CHANNEL_OFF
+    }
+    return pb.NotificationChannel_CHANNEL_IN_APP
+}
+
+func digestToProto(digest string) pb.DigestFrequency {
+    switch digest {
+    case domain.DigestDaily:
+        return pb.DigestFrequency_DIGEST_DAILY
+    case domain.DigestWeekly:
+        return pb.DigestFrequency_DIGEST_WEEKLY
+    }
+    return pb.DigestFrequency_DIGEST_OFF
+}
+
+func digestFromProto(digest pb.DigestFrequency) (string, error) {
+    switch digest {
+    case pb.DigestFrequency_DIGEST_OFF:
+        return domain.DigestOff, nil
+    case pb.DigestFrequency_DIGEST_DAILY:
+        return domain.DigestDaily, nil
+    case pb.DigestFrequency_DIGEST_WEEKLY:
+        return domain.DigestWeekly, nil
+    }
+    return "", status.Errorf(codes.InvalidArgument, "Unknown digest frequency %d", digest)
+}
+
+func channelFromProto(channel pb.NotificationChannel) (string, error) {
+    switch channel {
+    case pb.NotificationChannel_CHANNEL_IN_APP:
+        return domain.ChannelInApp, nil
+    case pb.NotificationChannel_CHANNEL_EMAIL:
+        return domain.ChannelEmail, nil
+    case pb.NotificationChannel_CHANNEL_OFF:
+        return domain.ChannelOff, nil
+    }
+    return "", status.Errorf(codes.InvalidArgument, "Unknown notification channel %d", channel)
+}
+
 func (h *UserHandler) UpdateUserProfile(ctx context.Context, req *pb.UpdateUserProfileRequest) (*pb.UpdateUserProfileResponse, error) {
     user, err := h.repo.FindByID(req.UserId)
     if err != nil {
@@ -991,11 +1101,43 @@ func (h *UserHandler) UpdateNotificationSettings(ctx context.Context, req *pb.Up
 
     user.PushNotificationsEnabled = req.EnablePush
     user.SubscribedToNewsletter = req.EnableEmail
+    if req.Locale != "" {
+        user.Locale = normalizeLocale(req.Locale)
+    }
+
+    var pref *domain.NotificationPreference
+    if p := req.Preferences; p != nil {
+        pref = &domain.NotificationPreference{UserID: user.ID}
+        fields := []struct {
+            dst *string
+            src pb.NotificationChannel
+        }{
+            {&pref.Likes, p.Likes},
+            {&pref.Comments, p.Comments},
+            {&pref.Follows, p.Follows},
+            {&pref.Mentions, p.Mentions},
+            {&pref.StoryReplies, p.StoryReplies},
+            {&pref.Messages, p.Messages},
+        }
+        for _, f := range fields {
+            if *f.dst, err = channelFromProto(f.src); err != nil {
+                return nil, err
+            }
+        }
+        if pref.Digest, err = digestFromProto(p.Digest); err != nil {
+            return nil, err
+        }
+    }
 
     err = h.repo.UpdateUser(user)
     if err != nil {
         return nil, status.Error(codes.Internal, "Failed to update notification settings")
     }
+    if pref != nil {
+        if err := h.repo.SaveNotificationPreference(pref); err != nil {
+            return nil, status.Error(codes.Internal, "Failed to update notification settings")
+        }
+    }
 
     return &pb.UpdateNotificationSettingsResponse{Success: true}, nil
 }
@@ -1183,14 +1325,21 @@ func (h *UserHandler) ReviewVerification(ctx context.Context, req *pb.ReviewVeri
     if req.Action == "ACCEPTED" {
         h.repo.VerifyUser(verificationReq.UserID)
 
-        user, _ := h.repo.FindByID(verificationReq.UserID.String())
-        
-        emailBody := "Congratulations! Your account has been verified."
-        task := EmailTask{Email: user.Email, Subject: "Verification Approved", Body: emailBody}
-        taskBody, _ := json.Marshal(task)
-        
-        h.amqpChan.PublishWithContext(ctx, "email_exchange", "send_email", false, false, 
-            amqp.Publishing{ContentType: "application/json", Body: taskBody})
+        user, err := h.repo.FindByID(verificationReq.UserID.String())
+        if err != nil {
+            log.Printf("Failed to load user %s for verification email: %v", verificationReq.UserID, err)
+            return &pb.Response{Success: true, Message: "Review processed"}, nil
+        }
+
+        task := events.EmailTask{
+            Email:    user.Email,
+            Template: events.TemplateVerificationApproved,
+            Data:     map[string]interface{}{"name": user.Name},
+            Locale:   user.Locale,
+        }
+        if err := h.events.SendEmail(ctx, task); err != nil {
+            log.Printf("Failed to queue verification email for %s: %v", user.ID, err)
+        }
     }
 
     return &pb.Response{Success: true, Message: "Review processed"}, nil
@@ -1230,20 +1379,15 @@ func (h *UserHandler) ReviewUserReport(ctx context.Context, req *pb.ReviewReport
 
         reporter, err := h.repo.FindByID(report.ReporterID.String())
         if err == nil {
-            emailBody := fmt.Sprintf("We have reviewed your report against user %s and have taken action.", report.ReportedUserID)
-            task := EmailTask{Email: reporter.Email, Subject: "Report Update", Body: emailBody}
-            
-            taskBody, _ := json.Marshal(task)
-            
-            h.amqpChan.PublishWithContext(ctx, 
-                "email_exchange", 
-                "send_email", 
-                false, 
-                false, 
-                amqp.Publishing{
-                    ContentType: "application/json", 
-                    Body: taskBody, 
-                })
+            task := events.EmailTask{
+                Email:    reporter.Email,
+                Template: events.TemplateReportUpdate,
+                Data:     map[string]interface{}{"name": reporter.Name},
+                Locale:   reporter.Locale,
+            }
+            if err := h.events.SendEmail(ctx, task); err != nil {
+                log.Printf("Failed to queue report update email for %s: %v", reporter.ID, err)
+            }
         }
     }
 
@@ -1278,5 +1422,5 @@ func (h *UserHandler) GetUserEmail(ctx context.Context, req *pb.GetUserEmailRequ
     if err != nil {
         return nil, status.Error(codes.NotFound, "User not found")
     }
-    return &pb.GetUserEmailResponse{Email: user.Email}, nil
+    return &pb.GetUserEmailResponse{Email: user.Email, Locale: user.Locale}, nil
 }
\ No newline at end of file
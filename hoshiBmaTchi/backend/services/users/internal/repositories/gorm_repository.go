@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Hinsane5/hoshiBmaTchi/backend/services/users/internal/core/domain"
@@ -22,6 +23,7 @@ func NewGormUserRepository(db *gorm.DB) *gormUserRepository{
 		&domain.HiddenStoryViewer{},
 		&domain.VerificationRequest{},
 		&domain.UserReport{},
+		&domain.NotificationPreference{},
 	)
     if err != nil {
     
@@ -60,6 +62,26 @@ func (r *gormUserRepository) FindByID(userID string) (*domain.User, error) {
 	return &user, nil
 }
 
+// FindByIDs returns the users that exist among userIDs, in no particular
+// order. IDs that are not UUIDs are skipped.
+func (r *gormUserRepository) FindByIDs(userIDs []string) ([]*domain.User, error) {
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	for _, s := range userIDs {
+		if id, err := uuid.Parse(s); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	var users []*domain.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *gormUserRepository) UpdatePassword(userID string, newPassword string) error {
 	result := r.db.Model(&domain.User{}).Where("id = ?", userID).Update("password", newPassword)
 	return result.Error
@@ -218,6 +240,19 @@ func (r *gormUserRepository) IsBlocked(userA, userB string) (bool, error) {
     return count > 0, err
 }
 
+// GetBlockRelationIDs returns the users this user blocked and the users who
+// blocked them.
+func (r *gormUserRepository) GetBlockRelationIDs(userID string) ([]string, []string, error) {
+    var blocked, blockedBy []string
+    if err := r.db.Model(&domain.Block{}).Where("blocker_id = ?", userID).Pluck("blocked_id", &blocked).Error; err != nil {
+        return nil, nil, err
+    }
+    if err := r.db.Model(&domain.Block{}).Where("blocked_id = ?", userID).Pluck("blocker_id", &blockedBy).Error; err != nil {
+        return nil, nil, err
+    }
+    return blocked, blockedBy, nil
+}
+
 func (r *gormUserRepository) AddCloseFriend(userID, targetID uuid.UUID) error {
 	cf := domain.CloseFriend{
 		ID:            uuid.New(),
@@ -274,6 +309,24 @@ func (r *gormUserRepository) UpdateUser(user *domain.User) error {
 	return r.db.Save(user).Error
 }
 
+// GetNotificationPreference returns the user's preferences, or the defaults
+// if they never changed them.
+func (r *gormUserRepository) GetNotificationPreference(userID uuid.UUID) (*domain.NotificationPreference, error) {
+	var pref domain.NotificationPreference
+	err := r.db.First(&pref, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DefaultNotificationPreference(userID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *gormUserRepository) SaveNotificationPreference(pref *domain.NotificationPreference) error {
+	return r.db.Save(pref).Error
+}
+
 func (r *gormUserRepository) GetAllUsers() ([]*domain.User, error) {
     var users []*domain.User
     err := r.db.Order("created_at desc").Find(&users).Error
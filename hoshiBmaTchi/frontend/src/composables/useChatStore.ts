@@ -14,6 +14,10 @@ const messages = ref<Message[]>([]);
 const selectedConversationId = ref<string | null>(null);
 const isConnected = ref(false);
 let socket: WebSocket | null = null;
+// lastSeq is the last event sequence seen, null until the first logged frame
+// arrives. Before that there is nothing to catch up on: history comes over
+// HTTP.
+let lastSeq: number | null = null;
 
 const callState = ref<"idle" | "dialing" | "incoming" | "connected">("idle");
 const activeCallType = ref<"audio" | "video">("video"); // Defined here
@@ -22,6 +26,9 @@ const incomingCaller = ref<{ id: string; name: string; avatar: string } | null>(
 );
 
 const outgoingCallInfo = ref<{ name: string; avatar: string } | null>(null);
+// activeCallId is the server's ID of the call this device is in or ringing
+// for. Signals about other calls are ignored.
+const activeCallId = ref<string | null>(null);
 
 const agoraClient = shallowRef<IAgoraRTCClient | null>(null);
 const localTracks = shallowRef<{
@@ -91,49 +98,51 @@ export function useChatStore() {
             new Date().toISOString(),
         }));
 
+        // Profiles and the display name come inline from the chat service.
+        // If its users lookup failed they are missing, and we fetch the
+        // profiles ourselves, once per user.
+        const lookups = new Map<string, Promise<any>>();
+        const lookup = (id: string) => {
+          if (!lookups.has(id)) {
+            lookups.set(
+              id,
+              usersApi
+                .getUserProfile(id)
+                .then((res) => res.data)
+                .catch(() => null)
+            );
+          }
+          return lookups.get(id)!;
+        };
+
         const enrichedConversations = await Promise.all(
           mappedConversations.map(async (conv: any) => {
             const myId = currentUser.value?.id;
             conv.participants = await Promise.all(
               conv.participants.map(async (p: any) => {
-                if (myId && p.id === myId && currentUser.value) {
-                  return {
-                    ...p,
-                    fullName:
-                      currentUser.value.fullName || currentUser.value.username,
-                    username: currentUser.value.username,
-                    avatar: currentUser.value.avatar || "/placeholder.svg",
-                  };
-                }
-                try {
-                  const userRes = await usersApi.getUserProfile(p.id);
-                  const userData = userRes.data;
-                  return {
-                    ...p,
-                    fullName: userData.name || userData.username,
-                    username: userData.username,
-                    avatar: userData.profile_picture_url || "/placeholder.svg",
-                  };
-                } catch (err) {
-                  return {
-                    ...p,
-                    fullName: "Unknown User",
-                    avatar: "/placeholder.svg",
-                  };
+                let profile = p;
+                if (!p.username) {
+                  profile = (await lookup(p.id)) || p;
                 }
+                return {
+                  ...p,
+                  username: profile.username,
+                  fullName: profile.name || profile.username || "Unknown User",
+                  avatar: profile.profile_picture_url || "/placeholder.svg",
+                };
               })
             );
 
+            if (conv.display_name) conv.name = conv.display_name;
             if (!conv.isGroup) {
-              const otherUser = conv.participants.find(
-                (p: any) => p.id !== myId
-              );
-              if (otherUser) {
-                conv.name = otherUser.fullName; 
-                conv.avatar = otherUser.avatar;
-              }
+              // A conversation with oneself has no other member.
+              const other =
+                conv.participants.find((p: any) => p.id !== myId) ||
+                conv.participants[0];
+              if (!conv.display_name && other) conv.name = other.fullName;
+              conv.avatar =
+                conv.display_avatar_url || other?.avatar || "/placeholder.svg";
             }
-
             return conv;
           })
         );
@@ -161,7 +170,7 @@ export function useChatStore() {
 
       if (res.ok) {
         const rawData = await res.json();
-        const mappedMessages = rawData.map((msg: any) => {
+        const mappedMessages = (rawData.messages || []).map((msg: any) => {
           const { name, avatar } = resolveSenderInfo(
             msg.sender_id,
             conversationId
@@ -198,18 +207,20 @@ export function useChatStore() {
     socket.onopen = () => {
       console.log("WS Connected");
       isConnected.value = true;
+      // Catch up on whatever was sent while this socket was down.
+      if (lastSeq !== null) {
+        socket?.send(JSON.stringify({ type: "resume", seq: lastSeq }));
+      }
     };
 
     socket.onmessage = (event) => {
       try {
         const data = JSON.parse(event.data);
 
-        if (data.type === "signal") {
-          handleSignal(data);
-        } else if (data.type === "group_created") {
-          fetchConversations();
-        } else if (data.type === "new_message") {
-          handleIncomingMessage(data);
+        if (data.type === "sync") {
+          handleSync(data);
+        } else {
+          handleFrame(data);
         }
       } catch (e) {
         console.error("WS Parse Error", e);
@@ -223,6 +234,37 @@ export function useChatStore() {
     };
   };
 
+  const handleFrame = (data: any) => {
+    if (typeof data.seq === "number") {
+      if (lastSeq !== null && data.seq <= lastSeq) return;
+      lastSeq = data.seq;
+    }
+
+    if (data.type === "signal") {
+      handleSignal(data);
+    } else if (data.type === "group_created") {
+      fetchConversations();
+    } else if (data.type === "new_message") {
+      handleIncomingMessage(data);
+    }
+  };
+
+  // handleSync replays missed events. After a reset the events are gone, so
+  // the conversation list is reloaded instead.
+  const handleSync = (batch: any) => {
+    if (batch.reset) {
+      lastSeq = batch.latest_seq;
+      fetchConversations();
+      return;
+    }
+    for (const event of batch.events) {
+      handleFrame({ ...event.frame, seq: event.seq });
+    }
+    if (batch.has_more) {
+      socket?.send(JSON.stringify({ type: "resume", seq: lastSeq }));
+    }
+  };
+
   const handleIncomingMessage = async (wsMsg: any) => {
     let conversation = conversations.value.find(
       (c) => c.id === wsMsg.conversation_id
@@ -273,32 +315,71 @@ export function useChatStore() {
     }
   };
 
+  // handleSignal follows the server's call state. Every device of every
+  // member gets each transition, including the sender's own, so decisions go
+  // by call_id and the local state rather than by who sent the frame.
   const handleSignal = (data: any) => {
-    if (data.sender_id === currentUser.value?.id) return;
+    const me = currentUser.value?.id;
+    const callerId = data.call?.caller_id;
 
     switch (data.signal_type) {
       case "incoming":
+        if (callerId === me) {
+          // Our own call: the dialing device learns its ID; our other
+          // devices stay quiet.
+          if (
+            callState.value !== "idle" &&
+            !activeCallId.value &&
+            selectedConversationId.value === data.conversation_id
+          ) {
+            activeCallId.value = data.call_id;
+          }
+          return;
+        }
         if (callState.value === "idle") {
           const { name, avatar } = resolveSenderInfo(
-            data.sender_id,
+            callerId || data.sender_id,
             data.conversation_id
           );
-          incomingCaller.value = { id: data.sender_id, name, avatar };
+          incomingCaller.value = { id: callerId || data.sender_id, name, avatar };
           activeCallType.value = data.call_type;
           selectedConversationId.value = data.conversation_id;
+          activeCallId.value = data.call_id;
           callState.value = "incoming";
         }
         break;
 
-      case "end":
+      case "accepted":
+        // Answered on another of our devices: stop ringing here.
         if (
-          callState.value !== "idle" &&
-          selectedConversationId.value === data.conversation_id
+          data.call_id === activeCallId.value &&
+          callState.value === "incoming" &&
+          data.sender_id === me
         ) {
-          leaveCall();
-          alert("Call ended");
+          resetCall();
         }
         break;
+
+      case "end":
+        if (callState.value === "idle" || data.call_id !== activeCallId.value) {
+          return;
+        }
+        resetCall();
+        if (data.sender_id !== me) {
+          alert(callEndedText(data.call?.status, callerId === me));
+        }
+        break;
+    }
+  };
+
+  const callEndedText = (status: string | undefined, outgoing: boolean) => {
+    switch (status) {
+      case "missed":
+        return outgoing ? "No answer" : "Missed call";
+      case "declined":
+        return outgoing ? "Call declined" : "Call ended";
+      default:
+        return "Call ended";
     }
   };
 
@@ -391,8 +472,7 @@ export function useChatStore() {
       socket?.send(JSON.stringify(signalPayload));
 
       callState.value = "connected";
-      const uid = Math.floor(Math.random() * 10000);
-      await initAgora(channel_name, agoraToken, app_id, uid);
+      await initAgora(channel_name, agoraToken, app_id, res.data.uid);
     } catch (e) {
       console.error("Call failed", e);
       callState.value = "idle";
@@ -412,39 +492,55 @@ export function useChatStore() {
 
       const { token: agoraToken, app_id, channel_name } = res.data;
 
+      socket?.send(
+        JSON.stringify({
+          type: "signal",
+          signal_type: "accept",
+          conversation_id: selectedConversationId.value,
+          call_id: activeCallId.value ?? undefined,
+        })
+      );
+
       callState.value = "connected";
-      const uid = Math.floor(Math.random() * 10000);
-      await initAgora(channel_name, agoraToken, app_id, uid);
+      await initAgora(channel_name, agoraToken, app_id, res.data.uid);
     } catch (e) {
       console.error("Accept failed", e);
       leaveCall();
     }
   };
 
-  const leaveCall = async () => {
+  // resetCall tears the call down on this device without telling the
+  // server, for when the server already finished it.
+  const resetCall = async () => {
+    const client = agoraClient.value;
     localTracks.value.audio?.close();
     localTracks.value.video?.close();
 
-    if (agoraClient.value) {
-      await agoraClient.value.leave();
+    callState.value = "idle";
+    activeCallId.value = null;
+    remoteUsers.value = [];
+    incomingCaller.value = null;
+    outgoingCallInfo.value = null;
+    localTracks.value = {};
+    agoraClient.value = null;
+
+    if (client) {
+      await client.leave();
     }
+  };
 
-    if (callState.value === "connected" && selectedConversationId.value) {
+  const leaveCall = async () => {
+    if (callState.value !== "idle" && selectedConversationId.value) {
       socket?.send(
         JSON.stringify({
           type: "signal",
-          signal_type: "end",
+          signal_type: callState.value === "incoming" ? "decline" : "end",
           conversation_id: selectedConversationId.value,
-          sender_id: currentUser.value?.id,
+          call_id: activeCallId.value ?? undefined,
         })
       );
     }
-
-    callState.value = "idle";
-    remoteUsers.value = [];
-    incomingCaller.value = null;
-    localTracks.value = {};
-    agoraClient.value = null;
+    await resetCall();
   };
 
   const toggleAudio = () => {
@@ -475,6 +571,7 @@ export function useChatStore() {
       content: content,
       media_type: type,
       media_url: mediaUrl,
+      client_msg_id: crypto.randomUUID(),
     };
 
     socket.send(JSON.stringify(payload));
@@ -422,6 +422,23 @@ export const markNotificationsRead = async (userId: string) => {
   return response.json();
 };
 
+// NotificationChannel mirrors the users service enum: 0 in-app, 1 email,
+// 2 off.
+export type NotificationChannel = 0 | 1 | 2;
+
+// DigestFrequency mirrors the users service enum: 0 off, 1 daily, 2 weekly.
+export type DigestFrequency = 0 | 1 | 2;
+
+export interface NotificationPreferences {
+  likes: NotificationChannel;
+  comments: NotificationChannel;
+  follows: NotificationChannel;
+  mentions: NotificationChannel;
+  story_replies: NotificationChannel;
+  messages: NotificationChannel;
+  digest: DigestFrequency;
+}
+
 export const settingsApi = {
   updateProfile: (data: {
     name: string;
@@ -436,10 +453,17 @@ export const settingsApi = {
     return apiClient.get("/v1/settings/preferences");
   },
 
-  updateNotifications: (enablePush: boolean, enableEmail: boolean) => {
+  updateNotifications: (
+    enablePush: boolean,
+    enableEmail: boolean,
+    preferences?: NotificationPreferences,
+    locale?: string
+  ) => {
     return apiClient.put("/v1/settings/notifications", {
       enable_push: enablePush,
       enable_email: enableEmail,
+      preferences,
+      locale,
     });
   },
 
@@ -1,6 +1,6 @@
 import { defineStore } from "pinia";
 import { ref, computed } from "vue";
-import type { Notification } from "@/types";
+import type { Notification, NotificationPush } from "@/types";
 import { markNotificationsRead } from "@/services/apiService";
 
 export const useNotificationStore = defineStore("notification", () => {
@@ -26,9 +26,14 @@ export const useNotificationStore = defineStore("notification", () => {
 
     socket.value.onmessage = (event) => {
       try {
-        const newNotification: Notification = JSON.parse(event.data);
+        const { event: kind, ...newNotification }: NotificationPush = JSON.parse(event.data);
         console.log("New Notification:", newNotification);
 
+        if (kind === "updated") {
+          notifications.value = notifications.value.filter(
+            (n) => n.ID !== newNotification.ID
+          );
+        }
         notifications.value.unshift(newNotification);
 
         toastMessage.value = newNotification;
@@ -39,5 +39,20 @@ export interface Notification {
   entity_id: string;
   message: string;
   is_read: boolean;
+  actor_count?: number;
+  actors?: NotificationActor[];
+  summary?: string;
+}
+
+export interface NotificationActor {
+  id: string;
+  name: string;
+  image: string;
+}
+
+// NotificationPush is a notification as it arrives over the socket. An
+// "updated" push replaces the notification with the same ID.
+export interface NotificationPush extends Notification {
+  event?: "created" | "updated";
 }
 